@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// apiWarnings collects the warnings Slack attaches to otherwise successful
+// responses, like deprecated methods or "missing_charset"
+type apiWarnings struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newAPIWarnings() *apiWarnings {
+	return &apiWarnings{seen: make(map[string]bool)}
+}
+
+// Record a method's warning, logging it the first time it's seen
+func (w *apiWarnings) add(method, warning string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := method + ": " + warning
+	if w.seen[key] {
+		return
+	}
+	w.seen[key] = true
+	log.Printf("Slack API warning from %s", key)
+}
+
+// Number of distinct warnings seen so far
+func (w *apiWarnings) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.seen)
+}
+
+// The HTTP client slack-go sends its requests through
+type httpDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// warningClient reads the warnings off API responses on their way back to slack-go, leaving the responses untouched
+type warningClient struct {
+	next     httpDoer
+	warnings *apiWarnings
+}
+
+// The parts of a response that carry warnings
+type warningResponse struct {
+	Warning          string `json:"warning"`
+	ResponseMetadata struct {
+		Warnings []string `json:"warnings"`
+	} `json:"response_metadata"`
+}
+
+// Do sends the request and notes any warnings in the response
+func (c *warningClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.next.Do(req)
+	if err != nil || !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		return resp, err
+	}
+
+	// Hand slack-go an unread copy of the body
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var parsed warningResponse
+	if json.Unmarshal(body, &parsed) != nil {
+		return resp, nil
+	}
+
+	// The top-level field joins several warnings with commas
+	method := path.Base(req.URL.Path)
+	for _, warning := range strings.Split(parsed.Warning, ",") {
+		if warning = strings.TrimSpace(warning); warning != "" {
+			c.warnings.add(method, warning)
+		}
+	}
+	for _, warning := range parsed.ResponseMetadata.Warnings {
+		c.warnings.add(method, warning)
+	}
+	return resp, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/slack-go/slack"
+)
+
+// Widest the channel details overlay grows to
+const channelInfoWidth = 60
+
+type channelInfoMsg struct {
+	channelID string
+	info      *slack.Channel
+	err       error
+}
+
+// Show a channel's topic, purpose, and member count, fetching them the first time
+func (m *Model) openChannelInfo(channelID string) tea.Cmd {
+	if channelID == "" || m.slackClient == nil {
+		return nil
+	}
+	m.channelInfoID = channelID
+	if _, ok := m.channelInfo[channelID]; ok {
+		m.currentPage = pageChannelInfo
+		return nil
+	}
+
+	m.isLoading = true
+	return func() tea.Msg {
+		info, err := m.slackClient.GetConversationInfo(&slack.GetConversationInfoInput{
+			ChannelID:         channelID,
+			IncludeNumMembers: true,
+		})
+		return channelInfoMsg{channelID: channelID, info: info, err: err}
+	}
+}
+
+// Cache fetched channel details and show them, or say why they can't be read
+func (m *Model) handleChannelInfo(msg channelInfoMsg) tea.Cmd {
+	m.isLoading = false
+	if msg.err != nil {
+		// Private channels the user isn't in can't be looked up at all
+		if msg.err.Error() == "channel_not_found" {
+			return m.showToast(tr("channel_info.no_access"), true)
+		}
+		return m.showToast(fmt.Sprintf(tr("channel_info.failed"), msg.err), true)
+	}
+
+	m.channelInfo[msg.channelID] = msg.info
+	if msg.channelID == m.channelInfoID && m.currentPage == pageChannelList {
+		m.currentPage = pageChannelInfo
+	}
+	return nil
+}
+
+// The channel details, one field per line
+func (m Model) channelInfoView() string {
+	info, ok := m.channelInfo[m.channelInfoID]
+	if !ok {
+		return ""
+	}
+
+	width, _ := m.chromeContentSize()
+	width = min(width-4, channelInfoWidth)
+
+	name := m.channelLabel(info.Name)
+	if info.IsIM || info.IsMpIM {
+		name = m.directName(*info)
+	}
+	lines := []string{titleStyle.Render(truncate(name, width-2))}
+
+	field := func(label, value string) {
+		if value == "" {
+			value = tr("channel_info.none")
+		}
+		lines = append(lines, infoStyle.Render(label), lipgloss.NewStyle().Width(width).Render(m.renderMrkdwn(value)), "")
+	}
+	field(tr("channel_info.topic"), info.Topic.Value)
+	field(tr("channel_info.purpose"), info.Purpose.Value)
+
+	if !info.IsIM {
+		lines = append(lines, plural(info.NumMembers, "channel_info.member", "channel_info.members"))
+	}
+	if info.IsArchived {
+		lines = append(lines, statusAwayStyle.Render(tr("channel_info.archived")))
+	}
+	if !info.IsMember && !info.IsIM && !info.IsMpIM {
+		lines = append(lines, helpStyle.Render(tr("channel_info.not_member")))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestChannelInfoDetails(t *testing.T) {
+	general := testChannel("C1", "general")
+	general.Topic.Value = "Release day is Thursday"
+	general.Purpose.Value = "Company-wide announcements"
+	general.NumMembers = 42
+	general.IsMember = true
+	client := &fakeSlack{info: map[string]*slack.Channel{"C1": &general}}
+	m := newTestModel(client)
+	m.currentPage = pageChannelList
+
+	for _, msg := range runCmd(m.openChannelInfo("C1")) {
+		updated, _ := m.handleMsg(msg)
+		m = updated.(Model)
+	}
+	equalCalls(t, client.called(), []string{`GetConversationInfo("C1")`})
+	if m.currentPage != pageChannelInfo {
+		t.Fatalf("page = %q, want the channel details", m.currentPage)
+	}
+
+	view := m.channelInfoView()
+	for _, want := range []string{"#general", "Release day is Thursday", "Company-wide announcements", plural(42, "channel_info.member", "channel_info.members")} {
+		if !strings.Contains(view, want) {
+			t.Errorf("details don't show %q:\n%s", want, view)
+		}
+	}
+
+	// The details are kept, so opening them again doesn't ask Slack
+	m.currentPage = pageChannelList
+	if cmd := m.openChannelInfo("C1"); cmd != nil || m.currentPage != pageChannelInfo {
+		t.Error("opening the details again fetched them instead of using the cached ones")
+	}
+}
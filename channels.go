@@ -0,0 +1,513 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// Characters in a channel's activity sparkline
+	sparklineWidth = 12
+
+	// How long a computed sparkline is reused before it's fetched again
+	sparklineTTL = 10 * time.Minute
+
+	// Messages read to compute a sparkline
+	sparklineHistoryLimit = 200
+
+	// Channels read for the combined view when none is selected
+	aggregateChannelLimit = 5
+
+	// Histories of the combined view fetched at the same time, to stay inside the rate limit
+	aggregateFetchConcurrency = 3
+
+	// Conversations read per page of conversations.list, and in all, so huge workspaces still start
+	conversationsPageSize = 200
+	conversationLimit     = 2000
+)
+
+// Block characters from quietest to busiest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// ChannelItem represents a channel in the channel picker
+type ChannelItem struct {
+	channel  slack.Channel
+	label    string
+	activity string
+	selected bool
+
+	// Presence of the other person, for direct messages
+	direct   bool
+	presence string
+
+	// Whether unsent compose text is waiting in the channel
+	draft bool
+
+	// Badge for the messages not read yet, e.g. "(3)"
+	unread string
+}
+
+// Implement the list.Item interface
+func (c ChannelItem) Title() string {
+	title := c.label
+	if c.channel.ID == "" {
+		title = tr("channels.all")
+	}
+	if c.direct {
+		title = presenceDot(c.presence) + " " + title
+	}
+	// A plain marker, since styling the title would throw off the filter's match highlighting
+	if c.selected {
+		title = "● " + title
+	}
+	if c.unread != "" {
+		title += " " + c.unread
+	}
+	if c.draft {
+		title += " " + tr("channels.draft")
+	}
+	return title
+}
+func (c ChannelItem) Description() string {
+	if c.activity != "" {
+		return c.activity
+	}
+	return c.channel.Topic.Value
+}
+func (c ChannelItem) FilterValue() string { return c.channel.Name }
+
+// A channel's cached activity sparkline
+type sparkline struct {
+	line    string
+	fetched time.Time
+}
+
+type sparklineMsg struct {
+	channelID string
+	counts    []int
+	total     int
+	err       error
+}
+
+// Read the conversations of the given types page by page, up to conversationLimit.
+// A page that fails ends the listing, returning the pages read before it along with the error.
+func fetchConversations(client SlackAPI, types ...string) ([]slack.Channel, error) {
+	var conversations []slack.Channel
+	cursor := ""
+	for {
+		page, next, err := client.GetConversations(&slack.GetConversationsParameters{
+			Cursor:          cursor,
+			ExcludeArchived: true,
+			Limit:           conversationsPageSize,
+			Types:           types,
+		})
+		if err != nil {
+			return conversations, err
+		}
+		conversations = append(conversations, page...)
+		if next == "" || len(conversations) >= conversationLimit {
+			break
+		}
+		cursor = next
+	}
+
+	if len(conversations) > conversationLimit {
+		log.Printf("Listed the first %d conversations of types %v, leaving out the rest", conversationLimit, types)
+		conversations = conversations[:conversationLimit]
+	}
+	return conversations, nil
+}
+
+// Channels shown together when none is selected. Limited to the first few to avoid rate limits.
+func (m Model) aggregateChannels() []string {
+	var ids []string
+	for i := 0; i < len(m.channels) && i < aggregateChannelLimit; i++ {
+		ids = append(ids, m.channels[i].ID)
+	}
+	return ids
+}
+
+// Fetch the latest messages of several channels at once, merged oldest first.
+// Channels whose history can't be read are left out and returned by name,
+// failing the whole fetch only when none could be read.
+func (m *Model) fetchAggregateMessages(channelIDs []string, limit int) ([]SlackMessage, []string, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		messages []SlackMessage
+		failed   []string
+		firstErr error
+	)
+	slots := make(chan struct{}, aggregateFetchConcurrency)
+
+	for _, channelID := range channelIDs {
+		wg.Add(1)
+		go func(channelID string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			channelMessages, _, err := m.fetchChannelMessages(channelID, limit, "")
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("Error fetching messages of %s: %v", channelID, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				failed = append(failed, m.channelName(channelID))
+				return
+			}
+			messages = append(messages, channelMessages...)
+		}(channelID)
+	}
+	wg.Wait()
+
+	if len(channelIDs) > 0 && len(failed) == len(channelIDs) {
+		return nil, nil, firstErr
+	}
+	sort.Strings(failed)
+
+	// Slack timestamps are unique per channel, so they order ties between channels too
+	sort.SliceStable(messages, func(i, j int) bool {
+		if !messages[i].Time.Equal(messages[j].Time) {
+			return messages[i].Time.Before(messages[j].Time)
+		}
+		return messages[i].Timestamp < messages[j].Timestamp
+	})
+	return messages, failed, nil
+}
+
+// The channel with the given name or ID, with or without its leading "#"
+func (m Model) findChannel(name string) (slack.Channel, bool) {
+	name = strings.TrimPrefix(name, "#")
+	for _, ch := range m.channels {
+		if ch.ID == name || ch.Name == name {
+			return ch, true
+		}
+	}
+	return slack.Channel{}, false
+}
+
+// Split a leading "#channel" target off a new message at the first space, tab, or
+// newline, e.g. "#general hello"
+func composeTarget(text string) (target, rest string, ok bool) {
+	if !strings.HasPrefix(text, "#") {
+		return "", "", false
+	}
+	i := strings.IndexFunc(text, unicode.IsSpace)
+	if i < 0 {
+		return "", "", false
+	}
+	target, rest = text[:i], strings.TrimSpace(text[i:])
+	return target, rest, len(target) > 1 && rest != ""
+}
+
+// How a channel is named in labels: "#general", or "acme/#general" with the
+// workspace prefix on, so same-named channels in other workspaces aren't confused
+func (m Model) channelLabel(name string) string {
+	// Direct messages are already named "@alice"
+	if !strings.HasPrefix(name, "@") {
+		name = "#" + name
+	}
+	if m.config.WorkspacePrefix && m.teamDomain != "" {
+		return m.teamDomain + "/" + name
+	}
+	return name
+}
+
+// Which channels the combined view covers, e.g. "across #general, #random, +3",
+// naming as many as fit in width
+func (m Model) aggregateSummary(width int) string {
+	ids := m.aggregateChannels()
+	if len(ids) == 0 {
+		return ""
+	}
+
+	var names []string
+	for i, id := range ids {
+		more := ""
+		if rest := len(ids) - i - 1; rest > 0 {
+			more = fmt.Sprintf(", +%d", rest)
+		}
+		label := m.channelLabel(m.channelName(id))
+		candidate := fmt.Sprintf(tr("channels.across"), strings.Join(append(names, label), ", ")+more)
+		if lipgloss.Width(candidate) > width && len(names) > 0 {
+			break
+		}
+		names = append(names, label)
+	}
+
+	summary := strings.Join(names, ", ")
+	if rest := len(ids) - len(names); rest > 0 {
+		summary += fmt.Sprintf(", +%d", rest)
+	}
+	return truncate(fmt.Sprintf(tr("channels.across"), summary), width)
+}
+
+// The channel last shown in this workspace, if it's still among the fetched
+// channels and direct messages; otherwise it's forgotten
+func (m *Model) lastChannel() string {
+	channelID := m.state.LastChannels[m.workspace]
+	if channelID == "" {
+		return ""
+	}
+	for _, ch := range append(append([]slack.Channel(nil), m.channels...), m.directMessages...) {
+		if ch.ID == channelID {
+			return channelID
+		}
+	}
+	delete(m.state.LastChannels, m.workspace)
+	return ""
+}
+
+// The page to reopen next session: the messages page while reading, including a
+// thread in it, and otherwise the main menu, as menus and prompts don't outlive a session
+func (m Model) resumablePage() string {
+	switch m.currentPage {
+	case pageMessages, pageThread:
+		return pageMessages
+	}
+	return pageMain
+}
+
+// Open the channel picker
+func (m *Model) openChannels() tea.Cmd {
+	m.textInput.Reset()
+	m.textInput.Focus()
+	m.currentPage = pageChannelList
+	return tea.Batch(m.filterChannels(), m.fetchDirectNames(), m.startPresenceRefresh(), m.fetchUnreadCounts())
+}
+
+// The picker's entries whose names contain the query: "all channels" at the top,
+// then the channels and the direct messages, or in presence order the direct
+// messages first with the people who are active ahead of those who are away.
+// Within each group, the ones with unread messages come first.
+func (m Model) channelItems(query string) []list.Item {
+	var channels, dms []list.Item
+	sorted := append([]slack.Channel(nil), m.channels...)
+	m.sortByUnread(sorted)
+	for _, ch := range sorted {
+		if strings.Contains(strings.ToLower(ch.Name), query) {
+			channels = append(channels, ChannelItem{channel: ch, label: m.channelLabel(ch.Name), activity: m.sparklines[ch.ID].line, selected: ch.ID == m.selectedChannelID, draft: m.hasDraft(ch.ID), unread: m.unreads[ch.ID].badge()})
+		}
+	}
+
+	direct := append([]slack.Channel(nil), m.directMessages...)
+	m.sortByUnread(direct)
+	if m.channelSort == channelSortPresence {
+		m.sortByPresence(direct)
+	}
+	for _, ch := range direct {
+		name := m.directName(ch)
+		if strings.Contains(strings.ToLower(name), query) {
+			dms = append(dms, ChannelItem{channel: ch, label: m.channelLabel(name), activity: m.sparklines[ch.ID].line, selected: ch.ID == m.selectedChannelID, direct: !ch.IsMpIM, presence: m.presence[ch.User], draft: m.hasDraft(ch.ID), unread: m.unreads[ch.ID].badge()})
+		}
+	}
+
+	items := []list.Item{ChannelItem{selected: m.selectedChannelID == ""}}
+	if m.channelSort == channelSortPresence {
+		return append(append(items, dms...), channels...)
+	}
+	return append(append(items, channels...), dms...)
+}
+
+// List the channels whose names contain what's typed in the filter, keeping the selected channel highlighted
+func (m *Model) filterChannels() tea.Cmd {
+	query := strings.ToLower(strings.TrimSpace(m.textInput.Value()))
+	items := m.channelItems(query)
+
+	selected := 0
+	for i, item := range items {
+		if item.(ChannelItem).selected {
+			selected = i
+		}
+	}
+
+	// While filtering, start on the best match rather than "all channels"
+	if query != "" && selected == 0 && len(items) > 1 {
+		selected = 1
+	}
+
+	m.channelList.Title = m.channelListTitle()
+	cmd := m.channelList.SetItems(items)
+	m.channelList.Select(selected)
+	return tea.Batch(cmd, m.fetchVisibleSparklines())
+}
+
+// Rebuild the picker after names or presence change, staying on the highlighted entry
+func (m *Model) refreshChannelList() tea.Cmd {
+	highlighted := ""
+	if item, ok := m.channelList.SelectedItem().(ChannelItem); ok {
+		highlighted = item.channel.ID
+	}
+
+	items := m.channelItems(strings.ToLower(strings.TrimSpace(m.textInput.Value())))
+	cmd := m.channelList.SetItems(items)
+	for i, item := range items {
+		if item.(ChannelItem).channel.ID == highlighted {
+			m.channelList.Select(i)
+		}
+	}
+	return cmd
+}
+
+// Compute sparklines for the channels on screen that don't have a fresh one
+func (m *Model) fetchVisibleSparklines() tea.Cmd {
+	if !m.config.Sparklines || m.slackClient == nil {
+		return nil
+	}
+
+	visible := m.channelList.VisibleItems()
+	start, end := m.channelList.Paginator.GetSliceBounds(len(visible))
+
+	var cmds []tea.Cmd
+	for _, item := range visible[start:end] {
+		ch, ok := item.(ChannelItem)
+		if !ok || ch.channel.ID == "" || m.sparklineRequested[ch.channel.ID] {
+			continue
+		}
+		if cached, ok := m.sparklines[ch.channel.ID]; ok && time.Since(cached.fetched) < sparklineTTL {
+			continue
+		}
+
+		m.sparklineRequested[ch.channel.ID] = true
+		channelID := ch.channel.ID
+		cmds = append(cmds, func() tea.Msg {
+			return m.fetchSparkline(channelID)
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// Count a channel's messages in each slice of the lookback window
+func (m *Model) fetchSparkline(channelID string) tea.Msg {
+	window := time.Duration(m.config.SparklineHours) * time.Hour
+	start := time.Now().Add(-window)
+
+	history, err := m.slackClient.GetConversationHistory(&slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Oldest:    fmt.Sprintf("%d.000000", start.Unix()),
+		Limit:     sparklineHistoryLimit,
+	})
+	if err != nil {
+		return sparklineMsg{channelID: channelID, err: err}
+	}
+
+	counts := make([]int, sparklineWidth)
+	bucket := window / sparklineWidth
+	for _, msg := range history.Messages {
+		i := int(parseSlackTimestamp(msg.Timestamp).Sub(start) / bucket)
+		if i >= 0 && i < sparklineWidth {
+			counts[i]++
+		}
+	}
+
+	return sparklineMsg{channelID: channelID, counts: counts, total: len(history.Messages)}
+}
+
+// Draw counts as a row of block characters scaled to the busiest slice
+func renderSparkline(counts []int) string {
+	peak := 0
+	for _, n := range counts {
+		peak = max(peak, n)
+	}
+
+	line := make([]rune, len(counts))
+	for i, n := range counts {
+		level := 0
+		if peak > 0 {
+			level = n * (len(sparkBlocks) - 1) / peak
+		}
+		line[i] = sparkBlocks[level]
+	}
+	return string(line)
+}
+
+// Cache a computed sparkline and show it in the picker
+func (m *Model) handleSparkline(msg sparklineMsg) {
+	delete(m.sparklineRequested, msg.channelID)
+	if msg.err != nil {
+		return
+	}
+
+	total := fmt.Sprint(msg.total)
+	if msg.total >= sparklineHistoryLimit {
+		total += "+"
+	}
+	line := fmt.Sprintf(tr("channels.activity"), renderSparkline(msg.counts), total, m.config.SparklineHours)
+	m.sparklines[msg.channelID] = sparkline{line: line, fetched: time.Now()}
+
+	for i, item := range m.channelList.Items() {
+		if ch, ok := item.(ChannelItem); ok && ch.channel.ID == msg.channelID {
+			ch.activity = line
+			m.channelList.SetItem(i, ch)
+			break
+		}
+	}
+}
+
+// Handle input on the channel picker: the arrows move through the list, enter
+// shows the chosen channel's messages, and everything else edits the filter
+func (m *Model) updateChannels(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "enter":
+		item, ok := m.channelList.SelectedItem().(ChannelItem)
+		if !ok {
+			return nil
+		}
+		return m.showChannel(item.channel.ID)
+	case "tab":
+		return m.toggleChannelSort()
+	case "ctrl+d":
+		if item, ok := m.channelList.SelectedItem().(ChannelItem); ok {
+			return m.openChannelInfo(item.channel.ID)
+		}
+		return nil
+	case "esc":
+		// Only reached with a filter to clear; otherwise esc goes back
+		m.textInput.Reset()
+		return m.filterChannels()
+	case "up", "down", "pgup", "pgdown":
+		var cmd tea.Cmd
+		m.channelList, cmd = m.channelList.Update(msg)
+		return tea.Batch(cmd, m.fetchVisibleSparklines())
+	}
+
+	before := m.textInput.Value()
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	if m.textInput.Value() != before {
+		return tea.Batch(cmd, m.filterChannels())
+	}
+	return cmd
+}
+
+// Show a channel's messages, or the latest from all channels for ""
+func (m *Model) showChannel(channelID string) tea.Cmd {
+	m.selectedChannelID = channelID
+	m.state.LastChannels[m.workspace] = channelID
+	m.selectedMessage = 0
+	m.fromUserID = ""
+	m.clearSearch()
+	m.currentPage = pageMessages
+	m.catchUpMarkers = m.catchUpStart()
+	m.isLoading = true
+	return tea.Batch(m.fetchMessages, m.fetchPins(channelID))
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+// A page of conversations with IDs numbered from start
+func conversationPage(start, size int) []slack.Channel {
+	page := make([]slack.Channel, size)
+	for i := range page {
+		page[i] = testChannel(fmt.Sprintf("C%d", start+i), fmt.Sprintf("channel-%d", start+i))
+	}
+	return page
+}
+
+func TestFetchConversationsPages(t *testing.T) {
+	client := &fakeSlack{conversations: [][]slack.Channel{
+		conversationPage(0, 3),
+		conversationPage(3, 3),
+		conversationPage(6, 2),
+	}}
+
+	channels, err := fetchConversations(client, "public_channel")
+	if err != nil {
+		t.Fatalf("fetchConversations: %v", err)
+	}
+	if len(channels) != 8 || channels[0].ID != "C0" || channels[7].ID != "C7" {
+		t.Errorf("got %d channels, want C0 through C7", len(channels))
+	}
+	equalCalls(t, client.called(), []string{
+		`GetConversations("")`,
+		`GetConversations("page1")`,
+		`GetConversations("page2")`,
+	})
+}
+
+func TestFetchConversationsCap(t *testing.T) {
+	// Eight pages of 300 pass the cap during the seventh
+	client := &fakeSlack{}
+	for i := 0; i < 8; i++ {
+		client.conversations = append(client.conversations, conversationPage(i*300, 300))
+	}
+
+	channels, err := fetchConversations(client, "public_channel")
+	if err != nil {
+		t.Fatalf("fetchConversations: %v", err)
+	}
+	if len(channels) != conversationLimit {
+		t.Errorf("got %d channels, want %d", len(channels), conversationLimit)
+	}
+	if calls := len(client.called()); calls != 7 {
+		t.Errorf("read %d pages, want 7", calls)
+	}
+}
+
+func TestFetchConversationsPageError(t *testing.T) {
+	client := &fakeSlack{
+		conversations:    [][]slack.Channel{conversationPage(0, 3), conversationPage(3, 3), conversationPage(6, 3)},
+		conversationsErr: map[int]error{1: errors.New("boom")},
+	}
+
+	channels, err := fetchConversations(client, "public_channel")
+	if err == nil {
+		t.Fatal("fetchConversations didn't report the failed page")
+	}
+	// The first page is kept, and nothing past the failed one is read
+	if len(channels) != 3 {
+		t.Errorf("got %d channels, want the first page's 3", len(channels))
+	}
+	equalCalls(t, client.called(), []string{`GetConversations("")`, `GetConversations("page1")`})
+}
+
+func TestFetchAggregateMessagesMergesChannels(t *testing.T) {
+	client := &fakeSlack{
+		users: map[string]string{"U1": "alice", "U2": "bob"},
+		history: map[string][]slack.Message{
+			"C1": {testMessage("U1", "general 2", "1700000005.000000"), testMessage("U1", "general 1", "1700000001.000000")},
+			"C2": {testMessage("U2", "random 2", "1700000004.000000"), testMessage("U2", "random 1", "1700000002.000000")},
+			"C3": {testMessage("U1", "dev 1", "1700000003.000000")},
+		},
+	}
+	m := newTestModel(client)
+
+	messages, failed, err := m.fetchAggregateMessages([]string{"C1", "C2", "C3"}, 10)
+	if err != nil {
+		t.Fatalf("fetchAggregateMessages: %v", err)
+	}
+	if len(failed) != 0 {
+		t.Errorf("failed = %q, want none", failed)
+	}
+
+	want := []string{"general 1", "random 1", "dev 1", "random 2", "general 2"}
+	if len(messages) != len(want) {
+		t.Fatalf("got %d messages, want %d", len(messages), len(want))
+	}
+	for i, text := range want {
+		if messages[i].Content != text {
+			t.Errorf("messages[%d] = %q, want %q", i, messages[i].Content, text)
+		}
+	}
+}
+
+func TestFetchAggregateMessagesPartialFailure(t *testing.T) {
+	client := &fakeSlack{
+		users: map[string]string{"U1": "alice"},
+		history: map[string][]slack.Message{
+			"C1": {testMessage("U1", "general", "1700000001.000000")},
+			"C3": {testMessage("U1", "dev", "1700000002.000000")},
+		},
+		historyErr: map[string]error{"C2": errors.New("channel_not_found")},
+	}
+	m := newTestModel(client)
+
+	messages, failed, err := m.fetchAggregateMessages([]string{"C1", "C2", "C3"}, 10)
+	if err != nil {
+		t.Fatalf("fetchAggregateMessages: %v", err)
+	}
+	if len(messages) != 2 || messages[0].Content != "general" || messages[1].Content != "dev" {
+		t.Errorf("messages = %+v, want general then dev", messages)
+	}
+	if len(failed) != 1 || failed[0] != "random" {
+		t.Errorf("failed = %q, want [random]", failed)
+	}
+
+	// The combined view still shows, with a warning naming the missing channel
+	m.currentPage = pageMessages
+	updated, _ := m.handleMsg(messagesMsg{messages: messages, failed: failed})
+	m = updated.(Model)
+	if len(m.messages) != 2 {
+		t.Errorf("showing %d messages, want 2", len(m.messages))
+	}
+	if want := fmt.Sprintf(tr("toast.channels_failed"), "#random"); m.toast != want || !m.toastIsError {
+		t.Errorf("toast = %q (error %v), want %q", m.toast, m.toastIsError, want)
+	}
+}
+
+func TestFetchAggregateMessagesAllFail(t *testing.T) {
+	client := &fakeSlack{historyErr: map[string]error{
+		"C1": errors.New("boom"),
+		"C2": errors.New("boom"),
+	}}
+	m := newTestModel(client)
+
+	if _, _, err := m.fetchAggregateMessages([]string{"C1", "C2"}, 10); err == nil {
+		t.Error("fetchAggregateMessages should fail when no channel loads")
+	}
+}
+
+func TestComposeTarget(t *testing.T) {
+	tests := []struct {
+		text, target, rest string
+		ok                 bool
+	}{
+		{"#general hello", "#general", "hello", true},
+		{"#general\thello", "#general", "hello", true},
+		{"#general\nhello\nthere", "#general", "hello\nthere", true},
+		{"#general", "", "", false},
+		{"#general   ", "", "", false},
+		{"# hello", "", "", false},
+		{"hello #general", "", "", false},
+	}
+	for _, tt := range tests {
+		target, rest, ok := composeTarget(tt.text)
+		if ok != tt.ok || ok && (target != tt.target || rest != tt.rest) {
+			t.Errorf("composeTarget(%q) = %q, %q, %v, want %q, %q, %v", tt.text, target, rest, ok, tt.target, tt.rest, tt.ok)
+		}
+	}
+}
+
+func TestDirectMessageNamedByUser(t *testing.T) {
+	client := &fakeSlack{users: map[string]string{"U1": "alice"}}
+	m := newTestModel(client)
+	im := testChannel("D1", "")
+	im.IsIM = true
+	im.User = "U1"
+	m.directMessages = []slack.Channel{im}
+
+	runCmd(m.fetchDirectNames())
+	equalCalls(t, client.called(), []string{`GetUsersInfo(["U1"])`})
+
+	for _, item := range m.channelItems("") {
+		if ch := item.(ChannelItem); ch.channel.ID == "D1" {
+			if ch.label != "@alice" {
+				t.Errorf("direct message label = %q, want @alice", ch.label)
+			}
+			return
+		}
+	}
+	t.Error("the direct message isn't in the picker")
+}
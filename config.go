@@ -0,0 +1,645 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Colors the theme accepts: hex like "#FFD966" or an ANSI color number like "214"
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|[0-9]{1,3})$`)
+
+// Config holds the user settings read from the config file
+type Config struct {
+	// Slack token, for when $SLACK_TOKEN can't be set, e.g. when started from a
+	// desktop launcher. TokenFile names a file holding just the token instead;
+	// a leading ~ is expanded. $SLACK_TOKEN takes precedence over both.
+	Token     string `json:"token"`
+	TokenFile string `json:"tokenFile"`
+
+	// Other workspaces to switch between at runtime, each with its own token.
+	// Without a token above, the app starts in the first one.
+	Workspaces []Workspace `json:"workspaces"`
+
+	// UI language, e.g. "en" or "es"
+	Locale string `json:"locale"`
+
+	// Message subtypes to leave out of the views, e.g. "channel_join" or "bot_message"
+	HideSubtypes []string `json:"hideSubtypes"`
+
+	// Users whose messages are hidden from the views, by user ID or handle
+	MutedUsers []string `json:"mutedUsers"`
+
+	// Emoji shortcodes mapped to the glyph to show, e.g. {"shipit": "🚀"}, taking
+	// precedence over the built-in table. Values may also be code points like "U+1F680".
+	Emoji map[string]string `json:"emoji"`
+
+	// Reactions offered by the reaction picker, most used first, as shortcodes like "eyes"
+	Reactions []string `json:"reactions"`
+
+	// Order the cycle-status key steps through
+	StatusCycle []string `json:"statusCycle"`
+
+	// The statuses Set Status offers, in order. The cycle-status key sets the first with each presence.
+	Statuses []StatusOption `json:"statuses"`
+
+	// Messages offered by Send Preset Message, replacing the built-in ones unless
+	// KeepBuiltinPresets adds them after those
+	Presets            []Preset `json:"presets"`
+	KeepBuiltinPresets bool     `json:"keepBuiltinPresets"`
+
+	// Show the newest messages at the top instead of the bottom
+	NewestFirst bool `json:"newestFirst"`
+
+	// Mark a channel as read once it has been viewed for MarkReadDelaySeconds
+	MarkReadOnView       bool `json:"markReadOnView"`
+	MarkReadDelaySeconds int  `json:"markReadDelaySeconds"`
+
+	// Briefly show which workspace and user the session connected as
+	StartupBanner bool `json:"startupBanner"`
+
+	// Start at the menu without fetching messages until a view is opened
+	SkipStartupFetch bool `json:"skipStartupFetch"`
+
+	// Load the whole user directory at startup, and again every UserRefreshMinutes
+	// (0 never), instead of looking names up as messages arrive. Off by default since
+	// it's costly in large workspaces; names it misses are still looked up one by one.
+	PrefetchUsers      bool `json:"prefetchUsers"`
+	UserRefreshMinutes int  `json:"userRefreshMinutes"`
+
+	// Set presence to away after this many minutes without a keypress (0 disables)
+	AwayAfterIdleMinutes int `json:"awayAfterIdleMinutes"`
+
+	// Proactively pace API calls to stay under Slack's per-method rate limits
+	RateLimit bool `json:"rateLimit"`
+
+	// Give up on connecting or fetching messages after this many seconds (0 waits forever)
+	TimeoutSeconds int `json:"timeoutSeconds"`
+
+	// Messages fetched from each channel for the combined view, and at a time for a
+	// single channel; HistoryHours leaves out anything older than that many hours (0 fetches all)
+	AllChannelsLimit   int `json:"allChannelsLimit"`
+	SingleChannelLimit int `json:"singleChannelLimit"`
+	HistoryHours       int `json:"historyHours"`
+
+	// Re-render the message view at most once per this many milliseconds while live messages arrive
+	LiveRenderIntervalMs int `json:"liveRenderIntervalMs"`
+
+	// Recurring status changes, evaluated in Timezone (an IANA name like
+	// "Europe/Madrid"; empty uses the system timezone). Dates in messages are shown in Timezone too.
+	StatusSchedule []StatusRule `json:"statusSchedule"`
+	Timezone       string       `json:"timezone"`
+
+	// Show a sparkline of each channel's message volume over the last SparklineHours
+	// in the channel picker. Off by default since it reads history for every channel shown.
+	Sparklines     bool `json:"sparklines"`
+	SparklineHours int  `json:"sparklineHours"`
+
+	// Order of the channel picker: "name", or "presence" to list direct messages
+	// first with the people who are active on top, refreshing their presence
+	// every PresenceRefreshMinutes while the picker is open
+	ChannelSort            string `json:"channelSort"`
+	PresenceRefreshMinutes int    `json:"presenceRefreshMinutes"`
+
+	// Where sending a preset or a message from Compose Message leaves you: "menu",
+	// "channel" to see the message land, or "compose" for a follow-up
+	AfterSend string `json:"afterSend"`
+
+	// For channels never seen before, treat messages from the last N hours as new (0 disables)
+	CatchUpWindowHours int `json:"catchUpWindowHours"`
+
+	// Click to select channels and messages and scroll with the wheel. Off by
+	// default, since capturing the mouse gets in the way of the terminal's own text selection.
+	Mouse bool `json:"mouse"`
+
+	// Lines PageUp/PageDown and the mouse wheel scroll the message view; 0 keeps
+	// the defaults of a screenful for the keys and 3 lines for the wheel
+	ScrollLines int `json:"scrollLines"`
+
+	// Name channels with the workspace in front, e.g. "acme/#general", in
+	// message labels, the header, and the channel picker and pane
+	WorkspacePrefix bool `json:"workspacePrefix"`
+
+	// File to append debug logging to, such as the warnings Slack attaches to API
+	// responses; empty turns it off
+	DebugLog string `json:"debugLog"`
+
+	// Count the distinct API warnings seen so far in the header
+	ShowAPIWarnings bool `json:"showApiWarnings"`
+
+	// Show only the message content, filling the terminal, on the messages and thread pages
+	FocusMode bool `json:"focusMode"`
+
+	// Ask "Quit? (y/n)" before quitting from the menu
+	ConfirmQuit bool `json:"confirmQuit"`
+
+	// Directory files are downloaded to; empty uses ~/Downloads/lazyslackui
+	DownloadDir string `json:"downloadDir"`
+
+	QuickNote QuickNote `json:"quickNote"`
+
+	Persona Persona `json:"persona"`
+
+	Keys KeyBindings `json:"keys"`
+
+	Theme Theme `json:"theme"`
+}
+
+// Theme holds the configurable look of the UI
+type Theme struct {
+	// Border styles: "rounded", "normal", "thick", "double", or "none"
+	AppBorder      string `json:"appBorder"`
+	ViewportBorder string `json:"viewportBorder"`
+
+	// Background the default colors are picked for: "auto" follows the terminal's, or "light" or "dark"
+	Mode string `json:"mode"`
+
+	// Color marking the selected channel: a hex color like "#FFD966" or an ANSI
+	// color number; empty uses the default for the mode
+	SelectedChannel string `json:"selectedChannel"`
+
+	// Colors of the borders and titles, the secondary text, the channel names
+	// and notifications, and errors, written the same way
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Accent    string `json:"accent"`
+	Error     string `json:"error"`
+}
+
+// StatusOption is a status Set Status offers: a presence and the custom status
+// text and emoji set with it. Without a name it's listed by its presence.
+type StatusOption struct {
+	Name       string `json:"name"`
+	Presence   string `json:"presence"`
+	StatusText string `json:"statusText"`
+	Emoji      string `json:"emoji"`
+}
+
+// Workspace is a named Slack workspace and the token to connect to it with,
+// given directly or in a file like the top-level token
+type Workspace struct {
+	Name      string `json:"name"`
+	Token     string `json:"token"`
+	TokenFile string `json:"tokenFile"`
+}
+
+// Preset is a message Send Preset Message offers; Description is the text sent
+type Preset struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// QuickNote opens the app straight into composing a message, skipping the menu
+type QuickNote struct {
+	// Channel name or ID to post to; empty starts at the menu as usual
+	Channel string `json:"channel"`
+
+	// Quit once the message is sent instead of starting another one
+	QuitAfterSend bool `json:"quitAfterSend"`
+}
+
+// Persona is a custom name and icon to post composed messages under; it needs a bot token
+type Persona struct {
+	Username  string `json:"username"`
+	IconEmoji string `json:"iconEmoji"`
+}
+
+// KeyBindings holds the configurable keys
+type KeyBindings struct {
+	// Steps to the next status in StatusCycle
+	CycleStatus string `json:"cycleStatus"`
+
+	// Pauses or resumes all recurring status changes
+	ToggleSchedule string `json:"toggleSchedule"`
+
+	// Re-reads the config file and applies it without restarting
+	ReloadConfig string `json:"reloadConfig"`
+
+	// Reloads the user directory used for names
+	RefreshUsers string `json:"refreshUsers"`
+
+	// Hides or brings back the chrome around the messages
+	FocusMode string `json:"focusMode"`
+
+	// Send the message being composed, and start a new line in it. Most terminals
+	// send the same thing for shift+enter as for enter, so the newline defaults to alt+enter.
+	SendMessage string `json:"sendMessage"`
+	NewLine     string `json:"newLine"`
+}
+
+// Default settings used when the config file is absent or leaves a field unset
+func defaultConfig() Config {
+	return Config{
+		Locale:      defaultLocale,
+		StatusCycle: []string{statusActive, statusAway, statusDND},
+		Statuses: []StatusOption{
+			{Presence: statusActive, StatusText: "Active", Emoji: ":white_check_mark:"},
+			{Presence: statusAway, StatusText: "Away", Emoji: ":away:"},
+			{Presence: statusDND, StatusText: "Do Not Disturb", Emoji: ":no_entry:"},
+		},
+		Reactions: []string{"thumbsup", "white_check_mark", "eyes", "tada", "heart", "joy"},
+
+		MarkReadDelaySeconds:   3,
+		RateLimit:              true,
+		TimeoutSeconds:         15,
+		AllChannelsLimit:       3,
+		SingleChannelLimit:     10,
+		StartupBanner:          true,
+		LiveRenderIntervalMs:   250,
+		SparklineHours:         24,
+		ChannelSort:            channelSortName,
+		AfterSend:              afterSendMenu,
+		PresenceRefreshMinutes: 5,
+		UserRefreshMinutes:     60,
+		Keys: KeyBindings{
+			CycleStatus:    "S",
+			ToggleSchedule: "R",
+			ReloadConfig:   "ctrl+l",
+			RefreshUsers:   "U",
+			FocusMode:      "z",
+			SendMessage:    "enter",
+			NewLine:        "alt+enter",
+		},
+		Theme: Theme{
+			AppBorder:      "rounded",
+			ViewportBorder: "rounded",
+			Mode:           themeAuto,
+		},
+	}
+}
+
+// Replace invalid settings with their defaults, returning a warning for each one
+func (c *Config) validate() []string {
+	var warnings []string
+	defaults := defaultConfig()
+
+	if _, ok := borderStyles[c.Theme.AppBorder]; !ok {
+		warnings = append(warnings, fmt.Sprintf(tr("config.unknown"), "theme.appBorder", c.Theme.AppBorder, defaults.Theme.AppBorder))
+		c.Theme.AppBorder = defaults.Theme.AppBorder
+	}
+	if _, ok := borderStyles[c.Theme.ViewportBorder]; !ok {
+		warnings = append(warnings, fmt.Sprintf(tr("config.unknown"), "theme.viewportBorder", c.Theme.ViewportBorder, defaults.Theme.ViewportBorder))
+		c.Theme.ViewportBorder = defaults.Theme.ViewportBorder
+	}
+
+	if !slices.Contains(themeModes, c.Theme.Mode) {
+		warnings = append(warnings, fmt.Sprintf(tr("config.unknown"), "theme.mode", c.Theme.Mode, defaults.Theme.Mode))
+		c.Theme.Mode = defaults.Theme.Mode
+	}
+	for _, color := range []struct {
+		name  string
+		value *string
+	}{
+		{"selectedChannel", &c.Theme.SelectedChannel},
+		{"primary", &c.Theme.Primary},
+		{"secondary", &c.Theme.Secondary},
+		{"accent", &c.Theme.Accent},
+		{"error", &c.Theme.Error},
+	} {
+		if *color.value != "" && !colorPattern.MatchString(*color.value) {
+			warnings = append(warnings, fmt.Sprintf(tr("config.not_color"), color.name, *color.value))
+			*color.value = ""
+		}
+	}
+
+	if c.AwayAfterIdleMinutes < 0 {
+		warnings = append(warnings, tr("config.idle_negative"))
+		c.AwayAfterIdleMinutes = 0
+	}
+
+	if c.TimeoutSeconds < 0 {
+		warnings = append(warnings, fmt.Sprintf(tr("config.timeout_negative"), defaults.TimeoutSeconds))
+		c.TimeoutSeconds = defaults.TimeoutSeconds
+	}
+
+	// Slack returns at most 1000 messages per history request
+	if c.AllChannelsLimit <= 0 || c.AllChannelsLimit > maxHistoryLimit {
+		warnings = append(warnings, fmt.Sprintf(tr("config.limit_range"), "allChannelsLimit", maxHistoryLimit, defaults.AllChannelsLimit))
+		c.AllChannelsLimit = defaults.AllChannelsLimit
+	}
+	if c.SingleChannelLimit <= 0 || c.SingleChannelLimit > maxHistoryLimit {
+		warnings = append(warnings, fmt.Sprintf(tr("config.limit_range"), "singleChannelLimit", maxHistoryLimit, defaults.SingleChannelLimit))
+		c.SingleChannelLimit = defaults.SingleChannelLimit
+	}
+	if c.HistoryHours < 0 {
+		warnings = append(warnings, tr("config.history_negative"))
+		c.HistoryHours = 0
+	}
+
+	if c.ScrollLines < 0 {
+		warnings = append(warnings, tr("config.scroll_negative"))
+		c.ScrollLines = 0
+	}
+
+	if c.LiveRenderIntervalMs <= 0 {
+		warnings = append(warnings, fmt.Sprintf(tr("config.not_positive"), "liveRenderIntervalMs", defaults.LiveRenderIntervalMs))
+		c.LiveRenderIntervalMs = defaults.LiveRenderIntervalMs
+	}
+
+	if c.UserRefreshMinutes < 0 {
+		warnings = append(warnings, fmt.Sprintf(tr("config.refresh_negative"), "userRefreshMinutes"))
+		c.UserRefreshMinutes = 0
+	}
+
+	if c.SparklineHours <= 0 {
+		warnings = append(warnings, fmt.Sprintf(tr("config.not_positive"), "sparklineHours", defaults.SparklineHours))
+		c.SparklineHours = defaults.SparklineHours
+	}
+
+	if c.Keys.SendMessage == c.Keys.NewLine {
+		warnings = append(warnings, fmt.Sprintf(tr("config.same_keys"), c.Keys.SendMessage, defaults.Keys.SendMessage, defaults.Keys.NewLine))
+		c.Keys.SendMessage = defaults.Keys.SendMessage
+		c.Keys.NewLine = defaults.Keys.NewLine
+	}
+
+	if !slices.Contains(channelSorts, c.ChannelSort) {
+		warnings = append(warnings, fmt.Sprintf(tr("config.unknown"), "channelSort", c.ChannelSort, defaults.ChannelSort))
+		c.ChannelSort = defaults.ChannelSort
+	}
+	if !slices.Contains(afterSendOptions, c.AfterSend) {
+		warnings = append(warnings, fmt.Sprintf(tr("config.unknown"), "afterSend", c.AfterSend, defaults.AfterSend))
+		c.AfterSend = defaults.AfterSend
+	}
+	if c.PresenceRefreshMinutes < 0 {
+		warnings = append(warnings, fmt.Sprintf(tr("config.refresh_negative"), "presenceRefreshMinutes"))
+		c.PresenceRefreshMinutes = 0
+	}
+
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			warnings = append(warnings, fmt.Sprintf(tr("config.timezone"), c.Timezone))
+			c.Timezone = ""
+		}
+	}
+
+	// Drop rules that can't be scheduled rather than guessing what they meant
+	var rules []StatusRule
+	for i, rule := range c.StatusSchedule {
+		if problem := rule.problem(); problem != "" {
+			warnings = append(warnings, fmt.Sprintf(tr("config.schedule_rule"), i, problem))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	c.StatusSchedule = rules
+
+	// Reactions may be written with or without their colons; each is offered once
+	var reactions []string
+	picked := make(map[string]bool)
+	for _, name := range c.Reactions {
+		name = strings.Trim(strings.TrimSpace(name), ":")
+		if name == "" || picked[name] {
+			continue
+		}
+		if !shortcodePattern.MatchString(":" + name + ":") {
+			warnings = append(warnings, fmt.Sprintf(tr("config.reaction"), name))
+			continue
+		}
+		picked[name] = true
+		reactions = append(reactions, name)
+	}
+	if len(reactions) == 0 {
+		reactions = defaults.Reactions
+	}
+	c.Reactions = reactions
+
+	// Statuses need a presence the app knows; emoji may be written without their colons
+	var statuses []StatusOption
+	for i, status := range c.Statuses {
+		if !validPresence(status.Presence) {
+			warnings = append(warnings, fmt.Sprintf(tr("config.status_presence"), i, status.Presence))
+			continue
+		}
+		if emoji := strings.Trim(strings.TrimSpace(status.Emoji), ":"); emoji != "" {
+			status.Emoji = ":" + emoji + ":"
+		}
+		status.Name = strings.TrimSpace(status.Name)
+		statuses = append(statuses, status)
+	}
+	if len(statuses) == 0 {
+		statuses = defaults.Statuses
+	}
+	c.Statuses = statuses
+
+	// Workspaces need a name to be picked by and a token to connect with
+	var workspaces []Workspace
+	named := make(map[string]bool)
+	for i, ws := range c.Workspaces {
+		ws.Name = strings.TrimSpace(ws.Name)
+		switch {
+		case ws.Name == "":
+			warnings = append(warnings, fmt.Sprintf(tr("config.workspace_no_name"), i))
+		case named[ws.Name]:
+			warnings = append(warnings, fmt.Sprintf(tr("config.workspace_twice"), ws.Name))
+		case strings.TrimSpace(ws.Token) == "" && ws.TokenFile == "":
+			warnings = append(warnings, fmt.Sprintf(tr("config.workspace_no_token"), ws.Name))
+		default:
+			named[ws.Name] = true
+			workspaces = append(workspaces, ws)
+		}
+	}
+	c.Workspaces = workspaces
+
+	// A preset with nothing to send is dropped; one without a name is listed by its text
+	var presets []Preset
+	for i, preset := range c.Presets {
+		preset.Name = strings.TrimSpace(preset.Name)
+		if strings.TrimSpace(preset.Description) == "" {
+			warnings = append(warnings, fmt.Sprintf(tr("config.preset_empty"), i))
+			continue
+		}
+		if preset.Name == "" {
+			preset.Name = preset.Description
+		}
+		presets = append(presets, preset)
+	}
+	c.Presets = presets
+
+	// Shortcodes may be written with or without their colons
+	emoji := make(map[string]string, len(c.Emoji))
+	for name, value := range c.Emoji {
+		glyph, err := parseEmojiGlyph(value)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf(tr("config.emoji"), name, err))
+			continue
+		}
+		emoji[strings.Trim(name, ":")] = glyph
+	}
+	c.Emoji = emoji
+
+	return warnings
+}
+
+// The Slack token to use and where it came from: $SLACK_TOKEN, then the
+// config's token, then its tokenFile. Empty if none of them has one.
+func (c Config) slackToken() (string, string, error) {
+	if token := strings.TrimSpace(os.Getenv("SLACK_TOKEN")); token != "" {
+		return token, "SLACK_TOKEN", nil
+	}
+	return readToken(c.Token, c.TokenFile)
+}
+
+// The token for a configured workspace, or the default one for an empty name
+func (c Config) workspaceToken(name string) (string, error) {
+	if name == "" {
+		token, _, err := c.slackToken()
+		return token, err
+	}
+	for _, ws := range c.Workspaces {
+		if ws.Name == name {
+			token, _, err := readToken(ws.Token, ws.TokenFile)
+			return token, err
+		}
+	}
+	return "", fmt.Errorf("no workspace named %q in the config file", name)
+}
+
+// A token given directly, or read from a file, along with where it came from
+func readToken(token, tokenFile string) (string, string, error) {
+	if token := strings.TrimSpace(token); token != "" {
+		return token, "config token", nil
+	}
+	if tokenFile == "" {
+		return "", "", nil
+	}
+
+	path, err := expandHome(tokenFile)
+	if err != nil {
+		return "", "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("reading tokenFile: %w", err)
+	}
+	return strings.TrimSpace(string(data)), path, nil
+}
+
+// Expand a leading ~ in a path to the home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// Path to the config file, respecting $XDG_CONFIG_HOME
+func configPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "lazyslackui", "config.json"), nil
+}
+
+// Load the config file, falling back to the defaults when it doesn't exist
+func loadConfig() (Config, error) {
+	cfg := defaultConfig()
+
+	path, err := configPath()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return defaultConfig(), fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Settings given on the command line, which take precedence over the config file
+type flagOverrides struct {
+	compose string
+	once    bool
+	noFetch bool
+	theme   string
+}
+
+// Apply the flags over a config read from the file
+func (f flagOverrides) apply(cfg *Config) {
+	if f.compose != "" {
+		cfg.QuickNote.Channel = f.compose
+	}
+	if f.once {
+		cfg.QuickNote.QuitAfterSend = true
+	}
+	if f.noFetch {
+		cfg.SkipStartupFetch = true
+	}
+	if f.theme != "" {
+		cfg.Theme.Mode = f.theme
+	}
+}
+
+// Re-read the config file and apply it live, keeping the current settings if it can't be parsed.
+// The command-line flags still take precedence, as they did at startup.
+func (m *Model) reloadConfig() tea.Cmd {
+	cfg, err := loadConfig()
+	if err != nil {
+		return m.showToast(fmt.Sprintf(tr("toast.config_reload_failed"), err), true)
+	}
+	m.flags.apply(&cfg)
+
+	setLocale(cfg.Locale)
+	warnings := cfg.validate()
+	m.config = cfg
+	applyTheme(cfg.Theme)
+	m.viewport.Style = m.viewport.Style.BorderStyle(borderStyles[cfg.Theme.ViewportBorder]).BorderForeground(primaryColor)
+	m.spinner.Style = m.spinner.Style.Foreground(primaryColor)
+	m.viewport.MouseWheelDelta = wheelLines(cfg)
+	m.newestFirst = cfg.NewestFirst
+	m.channelSort = cfg.ChannelSort
+	m.composeInput.KeyMap.InsertNewline.SetKeys(cfg.Keys.NewLine)
+
+	// Rebuild what was drawn in the old language and colors, as startup does
+	m.applyLocale()
+	m.applyListDelegates()
+	m.quickActions.SetItems(quickActionItems(cfg))
+	m.reactionOptions.SetItems(reactionItems(cfg))
+	m.presetMessages.SetItems(presetItems(cfg))
+	m.statusOptions.SetItems(statusItems(cfg))
+	m.applyLayout()
+
+	// Restart the timers whose settings may have changed
+	cmds := []tea.Cmd{m.restartIdleTick(), m.restartUsersRefresh()}
+
+	if cfg.Mouse {
+		cmds = append(cmds, tea.EnableMouseCellMotion)
+	} else {
+		cmds = append(cmds, tea.DisableMouse)
+	}
+	if m.scheduleEnabled {
+		cmds = append(cmds, m.startSchedule())
+	}
+
+	if len(warnings) > 0 {
+		cmds = append(cmds, m.showToast(fmt.Sprintf("%s: %s", tr("toast.config_reloaded"), strings.Join(warnings, "; ")), true))
+	} else {
+		cmds = append(cmds, m.showToast(tr("toast.config_reloaded"), false))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Write a config file where loadConfig will find it for the rest of the test
+func writeTestConfig(t *testing.T, config string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	path := filepath.Join(dir, "lazyslackui", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloadConfigKeepsFlags(t *testing.T) {
+	t.Cleanup(func() { setLocale(defaultConfig().Locale) })
+
+	m := newTestModel(&fakeSlack{})
+	m.flags = flagOverrides{compose: "general", once: true, noFetch: true, theme: themeLight}
+
+	writeTestConfig(t, `{"locale": "es", "skipStartupFetch": false, "theme": {"mode": "dark"}, "quickNote": {"channel": "random"}}`)
+	m.reloadConfig()
+
+	if m.config.QuickNote.Channel != "general" || !m.config.QuickNote.QuitAfterSend {
+		t.Errorf("quickNote = %+v, want the --compose and --once flags kept", m.config.QuickNote)
+	}
+	if !m.config.SkipStartupFetch {
+		t.Error("--no-fetch was lost on reload")
+	}
+	if m.config.Theme.Mode != themeLight {
+		t.Errorf("theme.mode = %q, want the --theme flag's %q", m.config.Theme.Mode, themeLight)
+	}
+
+	// The menu is rebuilt in the new language
+	first, _ := m.quickActions.Items()[0].(QuickAction)
+	if want := "1. " + tr("menu.view_messages"); first.name != want || want == "1. View Messages" {
+		t.Errorf("first quick action = %q, want %q in Spanish", first.name, want)
+	}
+	if m.quickActions.Title != tr("menu.quick_actions") {
+		t.Errorf("menu title = %q, want %q", m.quickActions.Title, tr("menu.quick_actions"))
+	}
+}
+
+func TestSlackTokenPrecedence(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("xoxp-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		env, token, tokenFile string
+		want, source          string
+	}{
+		{"xoxp-env", "xoxp-config", tokenFile, "xoxp-env", "SLACK_TOKEN"},
+		{"  ", "xoxp-config", tokenFile, "xoxp-config", "config token"},
+		{"", "", tokenFile, "xoxp-file", tokenFile},
+		{"", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Setenv("SLACK_TOKEN", tt.env)
+		token, source, err := Config{Token: tt.token, TokenFile: tt.tokenFile}.slackToken()
+		if err != nil {
+			t.Fatalf("slackToken: %v", err)
+		}
+		if token != tt.want || source != tt.source {
+			t.Errorf("slackToken() with env %q, token %q, file %q = %q from %q, want %q from %q",
+				tt.env, tt.token, tt.tokenFile, token, source, tt.want, tt.source)
+		}
+	}
+}
+
+func TestCustomPresets(t *testing.T) {
+	writeTestConfig(t, `{"presets": [
+		{"name": "Standup", "description": "Joining standup now"},
+		{"name": "Deploying", "description": "Deploying to production"}
+	]}`)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if warnings := cfg.validate(); len(warnings) != 0 {
+		t.Errorf("warnings = %q, want none", warnings)
+	}
+
+	items := presetItems(cfg)
+	if len(items) != 2 {
+		t.Fatalf("got %d presets, want 2", len(items))
+	}
+	if first := items[0].(QuickAction); first.name != "Standup" || first.description != "Joining standup now" {
+		t.Errorf("first preset = %+v, want Standup", first)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/slack-go/slack"
+)
+
+// Scopes the app needs for all of its features
+var requiredScopes = []string{
+	"channels:history",
+	"channels:read",
+	"chat:write",
+	"groups:history",
+	"groups:read",
+	"users:read",
+	"users:write",
+	"users.profile:write",
+}
+
+// Result states for a doctor check
+const (
+	checkPass = "PASS"
+	checkFail = "FAIL"
+	checkSkip = "SKIP"
+)
+
+// Print a single check result
+func reportCheck(result, name, detail string) {
+	fmt.Printf("[%s] %s: %s\n", result, name, detail)
+}
+
+// Run the setup checks outside the TUI and return the process exit code
+func runDoctor() int {
+	failed := false
+	fail := func(name, detail string) {
+		failed = true
+		reportCheck(checkFail, name, detail)
+	}
+
+	// Config file
+	path, err := configPath()
+	if err != nil {
+		fail("Config file", err.Error())
+	} else if _, err := os.Stat(path); os.IsNotExist(err) {
+		reportCheck(checkPass, "Config file", fmt.Sprintf("%s not present, using defaults", path))
+	} else if _, err := loadConfig(); err != nil {
+		fail("Config file", err.Error())
+	} else {
+		reportCheck(checkPass, "Config file", path)
+	}
+
+	// Token
+	cfg, _ := loadConfig()
+	token, source, err := cfg.slackToken()
+	if err != nil {
+		fail("Token", err.Error())
+	} else if token == "" {
+		fail("Token", "not set in SLACK_TOKEN, or in the config file's token or tokenFile")
+	}
+	if token == "" {
+		reportCheck(checkSkip, "Authentication", "no token")
+		reportCheck(checkSkip, "Scopes", "no token")
+		reportCheck(checkSkip, "RTM connection", "no token")
+	} else {
+		reportCheck(checkPass, "Token", "read from "+source)
+		client := slack.New(token)
+
+		// Authentication and scopes
+		auth, err := client.AuthTest()
+		if err != nil {
+			fail("Authentication", err.Error())
+			reportCheck(checkSkip, "Scopes", "authentication failed")
+			reportCheck(checkSkip, "RTM connection", "authentication failed")
+		} else {
+			reportCheck(checkPass, "Authentication", fmt.Sprintf("%s on %s", auth.User, auth.Team))
+
+			granted := make(map[string]bool)
+			for _, scope := range strings.Split(auth.Header.Get("X-OAuth-Scopes"), ",") {
+				granted[strings.TrimSpace(scope)] = true
+			}
+			for _, scope := range requiredScopes {
+				if granted[scope] {
+					reportCheck(checkPass, "Scope "+scope, "granted")
+				} else {
+					fail("Scope "+scope, "missing")
+				}
+			}
+
+			// RTM availability
+			if _, _, err := client.ConnectRTM(); err != nil {
+				fail("RTM connection", err.Error())
+			} else {
+				reportCheck(checkPass, "RTM connection", "available")
+			}
+		}
+	}
+
+	// Clipboard
+	if clipboard.Unsupported {
+		fail("Clipboard", "no clipboard utility found (install xclip, xsel, or wl-clipboard)")
+	} else {
+		reportCheck(checkPass, "Clipboard", "available")
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// Messages of history read when collecting a channel's files
+const downloadHistoryLimit = 200
+
+// A bulk download of a channel's files, one at a time
+type downloadJob struct {
+	channelID string
+	dir       string
+	files     []slack.File
+	next      int
+	results   []list.Item
+	saved     int
+	skipped   int
+	failed    int
+}
+
+type downloadFilesMsg struct {
+	channelID string
+	files     []slack.File
+	err       error
+}
+
+type downloadedFileMsg struct {
+	file    slack.File
+	path    string
+	skipped bool
+	err     error
+}
+
+// Where downloads are saved, expanding a leading ~
+func (m Model) downloadDir() (string, error) {
+	dir := m.config.DownloadDir
+	if dir == "" {
+		dir = "~/Downloads/lazyslackui"
+	}
+	return expandHome(dir)
+}
+
+// Start downloading every file shared in a channel's recent history
+func (m *Model) startDownload(channelID string) tea.Cmd {
+	if m.download != nil {
+		return m.showToast(tr("downloads.busy"), true)
+	}
+
+	dir, err := m.downloadDir()
+	if err != nil {
+		return m.showToast(fmt.Sprintf(tr("downloads.failed_start"), err), true)
+	}
+
+	m.download = &downloadJob{channelID: channelID, dir: dir}
+	return func() tea.Msg {
+		return m.collectFiles(channelID)
+	}
+}
+
+// Gather the downloadable files from a fresh read of a channel's history and the messages already loaded
+func (m *Model) collectFiles(channelID string) tea.Msg {
+	history, err := m.slackClient.GetConversationHistory(&slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Limit:     downloadHistoryLimit,
+	})
+	if err != nil {
+		return downloadFilesMsg{channelID: channelID, err: err}
+	}
+
+	seen := make(map[string]bool)
+	var files []slack.File
+	add := func(candidates []slack.File) {
+		for _, f := range openableFiles(candidates) {
+			if !seen[f.ID] && f.URLPrivateDownload != "" {
+				seen[f.ID] = true
+				files = append(files, f)
+			}
+		}
+	}
+	for _, msg := range history.Messages {
+		add(msg.Files)
+	}
+	for _, msg := range m.messages {
+		if msg.ChannelID == channelID {
+			add(msg.Files)
+		}
+	}
+
+	return downloadFilesMsg{channelID: channelID, files: files}
+}
+
+// Begin the downloads once the channel's files are known
+func (m *Model) handleDownloadFiles(msg downloadFilesMsg) tea.Cmd {
+	if m.download == nil || m.download.channelID != msg.channelID {
+		return nil
+	}
+	if msg.err != nil {
+		m.download = nil
+		return m.showToast(fmt.Sprintf(tr("downloads.failed_start"), msg.err), true)
+	}
+	if len(msg.files) == 0 {
+		m.download = nil
+		return m.showToast(fmt.Sprintf(tr("downloads.none"), m.channelName(msg.channelID)), false)
+	}
+	if err := os.MkdirAll(m.download.dir, 0o755); err != nil {
+		m.download = nil
+		return m.showToast(fmt.Sprintf(tr("downloads.failed_start"), err), true)
+	}
+
+	m.download.files = msg.files
+	return m.downloadNext()
+}
+
+// Download the job's next file
+func (m *Model) downloadNext() tea.Cmd {
+	job := m.download
+	f := job.files[job.next]
+	job.next++
+
+	return func() tea.Msg {
+		return m.downloadFile(f, job.dir)
+	}
+}
+
+// Save one file, skipping it if an earlier download already saved it. The
+// file is streamed to a partial file that's only renamed once complete, so a
+// failed download never looks finished.
+func (m *Model) downloadFile(f slack.File, dir string) tea.Msg {
+	// Prefix names with the file ID so same-named files don't overwrite each other
+	path := filepath.Join(dir, f.ID+"-"+filepath.Base(fileName(f)))
+	if _, err := os.Stat(path); err == nil {
+		return downloadedFileMsg{file: f, path: path, skipped: true}
+	}
+
+	err := m.fetchFile(f, path+".part")
+	if err != nil {
+		// Wait out a rate limit once before giving up on the file
+		var rateLimited *slack.RateLimitedError
+		if errors.As(err, &rateLimited) {
+			time.Sleep(rateLimited.RetryAfter)
+			err = m.fetchFile(f, path+".part")
+		}
+	}
+	if err == nil {
+		err = os.Rename(path+".part", path)
+	}
+	if err != nil {
+		os.Remove(path + ".part")
+	}
+
+	return downloadedFileMsg{file: f, path: path, err: err}
+}
+
+// Stream a file's contents to path with the token's credentials
+func (m *Model) fetchFile(f slack.File, path string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = m.slackClient.GetFile(f.URLPrivateDownload, out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Record a file's outcome and move on to the next, showing the summary after the last one
+func (m *Model) handleDownloadedFile(msg downloadedFileMsg) tea.Cmd {
+	job := m.download
+	if job == nil {
+		return nil
+	}
+
+	result := QuickAction{id: msg.file.ID, name: fileName(msg.file)}
+	switch {
+	case msg.err != nil:
+		job.failed++
+		result.description = fmt.Sprintf(tr("downloads.file_failed"), msg.err)
+	case msg.skipped:
+		job.skipped++
+		result.description = tr("downloads.file_skipped")
+	default:
+		job.saved++
+		result.description = fmt.Sprintf(tr("downloads.file_saved"), formatFileSize(msg.file.Size), msg.path)
+	}
+	job.results = append(job.results, result)
+
+	if job.next < len(job.files) {
+		return m.downloadNext()
+	}
+
+	m.download = nil
+	m.downloadList.Title = fmt.Sprintf(tr("downloads.title"), job.saved, job.skipped, job.failed)
+	m.downloadList.Select(0)
+	m.currentPage = pageDownloads
+	return tea.Batch(
+		m.downloadList.SetItems(job.results),
+		m.showToast(fmt.Sprintf(tr("downloads.done"), job.dir), job.failed > 0),
+	)
+}
+
+// Progress of the running download for the status line, or "" if there isn't one
+func (m Model) downloadProgress() string {
+	job := m.download
+	if job == nil {
+		return ""
+	}
+	if len(job.files) == 0 {
+		return tr("downloads.collecting")
+	}
+	return fmt.Sprintf(tr("downloads.progress"), job.next, len(job.files), fileName(job.files[job.next-1]))
+}
@@ -0,0 +1,45 @@
+package main
+
+import "strings"
+
+// Key a compose draft is kept under: the channel, or the channel and thread for
+// a reply. Edits have no draft, since the message itself holds the text.
+func composeDraftKey(mode string, msg SlackMessage) string {
+	switch mode {
+	case composeEdit:
+		return ""
+	case composeReply:
+		return msg.ChannelID + "/" + msg.threadRoot()
+	}
+	return msg.ChannelID
+}
+
+// Keep what's typed in compose for the next time this channel or thread is composed to
+func (m *Model) stashDraft() {
+	key := composeDraftKey(m.composeMode, m.composeMessage)
+	if key == "" {
+		return
+	}
+	if strings.TrimSpace(m.composeInput.Value()) == "" {
+		delete(m.state.Drafts, key)
+		return
+	}
+	m.state.Drafts[key] = m.composeInput.Value()
+}
+
+// Fill compose with the draft left for its channel or thread, if there is one
+func (m *Model) restoreDraft() {
+	if draft, ok := m.state.Drafts[composeDraftKey(m.composeMode, m.composeMessage)]; ok {
+		m.composeInput.SetValue(draft)
+	}
+}
+
+// Whether a draft is waiting in the channel or one of its threads
+func (m Model) hasDraft(channelID string) bool {
+	for key := range m.state.Drafts {
+		if key == channelID || strings.HasPrefix(key, channelID+"/") {
+			return true
+		}
+	}
+	return false
+}
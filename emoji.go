@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Emoji shortcodes as Slack writes them in message text, e.g. :tada:
+var shortcodePattern = regexp.MustCompile(`:([a-z0-9_+'-]+):`)
+
+// Glyphs for the most common shortcodes; the rest are left as text
+var builtinEmoji = map[string]string{
+	"+1":                    "👍",
+	"thumbsup":              "👍",
+	"-1":                    "👎",
+	"thumbsdown":            "👎",
+	"smile":                 "😄",
+	"slightly_smiling_face": "🙂",
+	"grinning":              "😀",
+	"joy":                   "😂",
+	"laughing":              "😆",
+	"wink":                  "😉",
+	"blush":                 "😊",
+	"thinking_face":         "🤔",
+	"sweat_smile":           "😅",
+	"cry":                   "😢",
+	"sob":                   "😭",
+	"scream":                "😱",
+	"sunglasses":            "😎",
+	"heart":                 "❤️",
+	"broken_heart":          "💔",
+	"tada":                  "🎉",
+	"fire":                  "🔥",
+	"rocket":                "🚀",
+	"eyes":                  "👀",
+	"clap":                  "👏",
+	"pray":                  "🙏",
+	"wave":                  "👋",
+	"ok_hand":               "👌",
+	"muscle":                "💪",
+	"raised_hands":          "🙌",
+	"point_up":              "☝️",
+	"white_check_mark":      "✅",
+	"heavy_check_mark":      "✔️",
+	"x":                     "❌",
+	"warning":               "⚠️",
+	"no_entry":              "⛔",
+	"question":              "❓",
+	"exclamation":           "❗",
+	"bulb":                  "💡",
+	"memo":                  "📝",
+	"calendar":              "📆",
+	"coffee":                "☕",
+	"beers":                 "🍻",
+	"pizza":                 "🍕",
+	"house":                 "🏠",
+	"house_with_garden":     "🏡",
+	"away":                  "🚶",
+	"robot_face":            "🤖",
+	"bug":                   "🐛",
+	"lock":                  "🔒",
+	"key":                   "🔑",
+	"link":                  "🔗",
+	"star":                  "⭐",
+	"sparkles":              "✨",
+	"zap":                   "⚡",
+	"boom":                  "💥",
+	"100":                   "💯",
+	"hourglass":             "⌛",
+	"stopwatch":             "⏱️",
+	"calling":               "📲",
+	"speech_balloon":        "💬",
+	"mag":                   "🔍",
+	"package":               "📦",
+	"construction":          "🚧",
+	"rotating_light":        "🚨",
+	"partying_face":         "🥳",
+	"see_no_evil":           "🙈",
+	"facepalm":              "🤦",
+	"shrug":                 "🤷",
+}
+
+// The reaction picker's choices in the configured order, shown with their glyphs where known
+func reactionItems(cfg Config) []list.Item {
+	items := make([]list.Item, len(cfg.Reactions))
+	for i, name := range cfg.Reactions {
+		glyph, _ := emojiGlyph(cfg, name)
+		items[i] = ReactionOption{name: name, emoji: glyph}
+	}
+	return items
+}
+
+// The glyph for a shortcode, the user's own mapping taking precedence over the built-in one
+func emojiGlyph(cfg Config, name string) (string, bool) {
+	if glyph, ok := cfg.Emoji[name]; ok {
+		return glyph, true
+	}
+	glyph, ok := builtinEmoji[name]
+	return glyph, ok
+}
+
+// Replace the shortcodes with glyphs, leaving unknown ones as typed
+func (m Model) renderEmoji(text string) string {
+	return shortcodePattern.ReplaceAllStringFunc(text, func(shortcode string) string {
+		if glyph, ok := emojiGlyph(m.config, strings.Trim(shortcode, ":")); ok {
+			return glyph
+		}
+		return shortcode
+	})
+}
+
+// Turn a configured emoji into the glyph to show. Accepts the glyph itself or a
+// code point sequence like "U+1F680" or "U+2764 U+FE0F".
+func parseEmojiGlyph(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", errors.New(tr("config.emoji_empty"))
+	}
+
+	if strings.HasPrefix(strings.ToUpper(value), "U+") {
+		var glyph strings.Builder
+		for _, part := range strings.Fields(value) {
+			if !strings.HasPrefix(strings.ToUpper(part), "U+") {
+				return "", fmt.Errorf(tr("config.emoji_code_point"), part)
+			}
+			code, err := strconv.ParseUint(part[2:], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(code)) {
+				return "", fmt.Errorf(tr("config.emoji_code_point"), part)
+			}
+			glyph.WriteRune(rune(code))
+		}
+		return glyph.String(), nil
+	}
+
+	if !utf8.ValidString(value) {
+		return "", errors.New(tr("config.emoji_utf8"))
+	}
+	for _, r := range value {
+		if unicode.IsControl(r) {
+			return "", errors.New(tr("config.emoji_control"))
+		}
+	}
+	return value, nil
+}
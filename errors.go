@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// How long to wait before retrying a request that couldn't reach Slack
+const networkRetryDelay = 5 * time.Second
+
+// Slack error codes that mean the token itself can't be used
+var authErrorCodes = map[string]bool{
+	"not_authed":       true,
+	"invalid_auth":     true,
+	"account_inactive": true,
+	"token_revoked":    true,
+	"token_expired":    true,
+}
+
+// appError describes what failed and the error behind it, if any
+type appError struct {
+	text string
+	err  error
+}
+
+func (e appError) Error() string {
+	if e.err == nil {
+		return e.text
+	}
+	return e.text + ": " + e.err.Error()
+}
+
+// The token is missing or rejected, so nothing works until it's fixed
+type authErrMsg struct{ appError }
+
+// The token lacks a scope the request needs
+type scopeErrMsg struct{ appError }
+
+// Slack throttled the request; retry, if set, re-issues it once the wait is over
+type rateLimitErrMsg struct {
+	appError
+	retryAfter time.Duration
+	retry      tea.Cmd
+}
+
+// Slack couldn't be reached; retry, if set, re-issues the request
+type networkErrMsg struct {
+	appError
+	retry tea.Cmd
+}
+
+// Any other failure
+type genericErrMsg struct{ appError }
+
+// An error with nothing more to it than its description
+func genericErr(text string) tea.Msg {
+	return genericErrMsg{appError{text: text}}
+}
+
+// Wrap a failed request's error in the message type Update reacts to. retry
+// re-issues the request after transient failures; pass nil for requests that
+// aren't safe to repeat, like posting a message.
+func classifyError(text string, err error, retry tea.Cmd) tea.Msg {
+	base := appError{text: text, err: err}
+
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return rateLimitErrMsg{appError: base, retryAfter: rateLimited.RetryAfter, retry: retry}
+	}
+
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		if authErrorCodes[slackErr.Err] {
+			return authErrMsg{base}
+		}
+		if slackErr.Err == "missing_scope" {
+			return scopeErrMsg{base}
+		}
+	}
+
+	// Timeouts, refused connections, failed DNS lookups, and Slack's own outages
+	var netErr net.Error
+	var statusErr slack.StatusCodeError
+	if errors.As(err, &netErr) || (errors.As(err, &statusErr) && statusErr.Code >= 500) {
+		return networkErrMsg{appError: base, retry: retry}
+	}
+
+	return genericErrMsg{base}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Answers every Slack API request with a canned body, keeping the paths asked for
+type cannedTransport struct {
+	mu    sync.Mutex
+	paths []string
+	body  string
+}
+
+func (c *cannedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.paths = append(c.paths, req.URL.Path)
+	c.mu.Unlock()
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestStartupAuthError(t *testing.T) {
+	transport := &cannedTransport{body: `{"ok": false, "error": "invalid_auth"}`}
+	saved := http.DefaultTransport
+	http.DefaultTransport = transport
+	t.Cleanup(func() { http.DefaultTransport = saved })
+	t.Setenv("SLACK_TOKEN", "xoxp-revoked")
+
+	m := initialModel(defaultConfig(), State{LastSeen: make(map[string]string), Drafts: make(map[string]string), LastChannels: make(map[string]string)})
+	msg := m.initSlackClient()
+	if _, ok := msg.(authErrMsg); !ok {
+		t.Fatalf("initSlackClient = %T, want authErrMsg", msg)
+	}
+
+	updated, _ := m.handleMsg(msg)
+	m = updated.(Model)
+	if !strings.Contains(m.error, "invalid_auth") || m.isLoading {
+		t.Errorf("error = %q, loading %v; want invalid_auth shown and loading stopped", m.error, m.isLoading)
+	}
+	if m.rtm != nil || m.slackClient != nil {
+		t.Error("a rejected token left a connection behind")
+	}
+	// Nothing past the token check is asked for, the live connection included
+	if len(transport.paths) != 1 || !strings.HasSuffix(transport.paths[0], "/auth.test") {
+		t.Errorf("requested %q, want only auth.test", transport.paths)
+	}
+}
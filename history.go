@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Most messages a single history request can return
+const maxHistoryLimit = 1000
+
+type olderMessagesMsg struct {
+	channelID string
+	messages  []SlackMessage
+	cursor    string
+	err       error
+}
+
+// Fetch the page of the selected channel's history before what's loaded, or
+// say there's nothing older
+func (m *Model) loadOlderMessages() tea.Cmd {
+	if m.selectedChannelID == "" || m.slackClient == nil || m.loadingOlder {
+		return nil
+	}
+	if m.historyCursor == "" {
+		return m.showToast(tr("toast.history_start"), false)
+	}
+
+	m.loadingOlder = true
+	m.isLoading = true
+	channelID, cursor := m.selectedChannelID, m.historyCursor
+	return func() tea.Msg {
+		messages, next, err := m.fetchChannelMessages(channelID, m.config.SingleChannelLimit, cursor)
+		return olderMessagesMsg{channelID: channelID, messages: messages, cursor: next, err: err}
+	}
+}
+
+// Load older history when the selection is about to move past the oldest loaded message
+func (m *Model) loadOlderAtEdge(delta int) tea.Cmd {
+	oldest := 0
+	if m.newestFirst {
+		oldest = len(m.visibleMessages()) - 1
+		delta = -delta
+	}
+	if delta >= 0 || m.selectedMessage != oldest || m.historyCursor == "" {
+		return nil
+	}
+	return m.loadOlderMessages()
+}
+
+// Put the older page in front of the loaded messages, keeping the same message selected
+func (m *Model) handleOlderMessages(msg olderMessagesMsg) tea.Cmd {
+	m.loadingOlder = false
+	m.isLoading = false
+
+	// Drop pages for a channel the user has since left
+	if msg.channelID != m.selectedChannelID {
+		return nil
+	}
+	if msg.err != nil {
+		return m.showToast(fmt.Sprintf("%s: %v", tr("error.fetch_older"), msg.err), true)
+	}
+
+	selected, hadSelection := m.selectedMsg()
+	m.messages = append(msg.messages, m.messages...)
+	m.historyCursor = msg.cursor
+	m.following = false
+
+	if hadSelection {
+		for i, visible := range m.visibleMessages() {
+			if visible.ChannelID == selected.ChannelID && visible.Timestamp == selected.Timestamp {
+				m.selectedMessage = i
+				break
+			}
+		}
+	}
+	m.refreshMessages()
+	m.scrollToSelected()
+	return nil
+}
+
+// The line at the oldest end of a channel's messages: a hint that there's more, or
+// a marker once its whole history is loaded
+func (m Model) historyEdge() string {
+	if m.selectedChannelID == "" || m.fromUserID != "" {
+		return ""
+	}
+	if m.historyCursor == "" {
+		return infoStyle.Render(tr("messages.history_start"))
+	}
+	return helpStyle.Render(tr("messages.history_more"))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestLoadOlderMessagesUsesCursor(t *testing.T) {
+	client := &fakeSlack{
+		users:        map[string]string{"U1": "alice"},
+		history:      map[string][]slack.Message{"C1": {testMessage("U1", "newest", "1700000003.000000")}},
+		historyNext:  map[string]string{"C1": "older1"},
+		olderHistory: map[string][]slack.Message{"older1": {testMessage("U1", "oldest", "1700000001.000000")}},
+	}
+	m := newTestModel(client)
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C1"
+
+	updated, _ := m.handleMsg(m.fetchMessages())
+	m = updated.(Model)
+	if m.historyCursor != "older1" {
+		t.Fatalf("historyCursor = %q, want older1", m.historyCursor)
+	}
+
+	msgs := runCmd(m.loadOlderMessages())
+	if len(msgs) != 1 {
+		t.Fatalf("loadOlderMessages produced %d messages, want 1", len(msgs))
+	}
+	updated, _ = m.handleMsg(msgs[0])
+	m = updated.(Model)
+
+	equalCalls(t, client.called(), []string{
+		`GetConversationHistory("C1")`,
+		`GetConversationHistory("C1", "older1")`,
+	})
+	if len(m.messages) != 2 || m.messages[0].Content != "oldest" || m.messages[1].Content != "newest" {
+		t.Errorf("messages = %+v, want oldest then newest", m.messages)
+	}
+	// The first page of history has no older one
+	if m.historyCursor != "" {
+		t.Errorf("historyCursor = %q after the last page, want empty", m.historyCursor)
+	}
+}
@@ -0,0 +1,675 @@
+package main
+
+// Locale used when the config doesn't set one or a string is missing a translation
+const defaultLocale = "en"
+
+// Active UI locale, set from the config at startup
+var activeLocale = defaultLocale
+
+// UI strings keyed by locale, then by string key
+var translations = map[string]map[string]string{
+	"en": {
+		"app.initializing":               "Initializing...",
+		"app.too_small":                  "Terminal too small (need at least %dx%d)",
+		"app.loading":                    "Loading...",
+		"app.error":                      "Error: %s",
+		"app.header":                     "Slack TUI - Logged in as: %s",
+		"app.footer":                     "q/ctrl+c: quit • esc: back • ↑/↓/k/j: navigate • enter: select",
+		"messages.footer":                "esc: back • ↑/↓: select • g/G: oldest/newest • r: refresh • a: actions",
+		"footer.thread":                  "esc: back • ↑/↓: scroll • y: copy thread",
+		"footer.compose":                 "%s: send • esc: cancel",
+		"footer.channels":                "type to filter • ↑/↓: navigate • enter: open • esc: back",
+		"footer.status":                  "esc: back • ↑/↓/k/j: navigate • enter: set status",
+		"footer.list":                    "esc: back • ↑/↓/k/j: navigate • enter: select",
+		"footer.back":                    "esc: back",
+		"channel_info.topic":             "Topic",
+		"channel_info.purpose":           "Purpose",
+		"channel_info.none":              "None set",
+		"channel_info.member":            "%d member",
+		"channel_info.members":           "%d members",
+		"channel_info.archived":          "Archived",
+		"channel_info.not_member":        "You're not a member of this channel",
+		"channel_info.no_access":         "Can't read this channel's details: it's private and you're not in it",
+		"channel_info.failed":            "Error getting the channel's details: %v",
+		"menu.quick_actions":             "Quick Actions",
+		"menu.view_messages":             "View Messages",
+		"menu.view_messages.d":           "View recent messages from Slack",
+		"menu.channels":                  "Browse Channels",
+		"menu.channels.d":                "Pick a channel to read",
+		"menu.set_status":                "Set Status",
+		"menu.set_status.d":              "Change your Slack status",
+		"menu.send_preset":               "Send Preset Message",
+		"menu.send_preset.d":             "Send a pre-configured message",
+		"menu.compose":                   "Compose Message",
+		"menu.compose.d":                 "Write a message to the selected channel",
+		"menu.people":                    "People",
+		"menu.people.d":                  "Browse members of the workspace",
+		"menu.settings":                  "Settings",
+		"menu.settings.d":                "Change the app settings",
+		"menu.workspaces":                "Switch Workspace",
+		"menu.workspaces.d":              "Connect to another of your workspaces",
+		"menu.mentions":                  "Needs Reply",
+		"menu.mentions.d":                "Mentions of you that you haven't answered",
+		"menu.quit":                      "Quit",
+		"menu.quit.d":                    "Exit the application",
+		"quit.confirm":                   "Quit? (y/n)",
+		"presets.title":                  "Preset Messages",
+		"status.title":                   "Set Status",
+		"statusclear.title":              "Clear Status After",
+		"statusclear.30m":                "30 minutes",
+		"statusclear.1h":                 "1 hour",
+		"statusclear.4h":                 "4 hours",
+		"statusclear.today":              "Today",
+		"statusclear.never":              "Don't clear",
+		"workspaces.title":               "Switch Workspace",
+		"workspaces.default":             "Default",
+		"workspaces.default.d":           "The workspace of SLACK_TOKEN or the config's token",
+		"workspaces.other.d":             "Switch to this workspace",
+		"workspaces.active.d":            "Connected now",
+		"status.active":                  "Active",
+		"status.active.d":                "Set your status to active",
+		"status.away":                    "Away",
+		"status.away.d":                  "Set your status to away",
+		"status.dnd":                     "Do Not Disturb",
+		"status.dnd.d":                   "Set your status to do not disturb",
+		"status.unknown":                 "Unknown",
+		"status.cleared":                 "No status",
+		"status.clear":                   "Clear Status",
+		"status.clear.d":                 "Remove your custom status and go back to automatic presence",
+		"status.idle_away":               "Away (idle)",
+		"messages.empty":                 "No messages found.",
+		"messages.in_channel":            "in",
+		"messages.unknown":               "Unknown User",
+		"messages.from_user":             "Showing only messages from %s (f to clear)",
+		"messages.muted_hidden":          "%d hidden (M to show)",
+		"messages.last_activity":         "#%s: last activity %s (%s)",
+		"messages.thread_reply":          "(reply in thread)",
+		"messages.reply":                 "%d reply",
+		"messages.replies":               "%d replies",
+		"details.sent":                   "Sent %s",
+		"details.edited":                 "Edited %s by %s",
+		"details.ts":                     "ts %s • channel %s • user %s",
+		"details.thread_ts":              "• thread %s",
+		"details.link":                   "Link %s",
+		"messages.thread_reply_to":       "(reply in thread to %s)",
+		"layout.too_narrow":              "Split layout saved; it appears once the window is wider",
+		"focus.on":                       "Focus mode: press %s to bring back the menus",
+		"banner.session":                 "Connected to %s as @%s • %d channels • %s",
+		"banner.live":                    "live updates",
+		"banner.user_token":              "user token",
+		"banner.bot_token":               "bot token",
+		"app.api_warnings":               "⚠ %d API warnings",
+		"app.reconnecting":               "reconnecting…",
+		"date.today":                     "today",
+		"date.yesterday":                 "yesterday",
+		"date.tomorrow":                  "tomorrow",
+		"date.just_now":                  "just now",
+		"date.minute":                    "%d minute",
+		"date.minutes":                   "%d minutes",
+		"date.hour":                      "%d hour",
+		"date.hours":                     "%d hours",
+		"date.day":                       "%d day",
+		"date.days":                      "%d days",
+		"date.ago":                       "%s ago",
+		"date.in":                        "in %s",
+		"toast.users_loading":            "Loading the user directory...",
+		"toast.users_loaded":             "Loaded %d users",
+		"toast.users_failed":             "Error loading the user directory: %v",
+		"downloads.title":                "Downloads: %d saved, %d skipped, %d failed",
+		"downloads.busy":                 "A download is already running",
+		"downloads.failed_start":         "Couldn't start the download: %v",
+		"downloads.none":                 "No files to download in #%s",
+		"downloads.collecting":           "Collecting files...",
+		"downloads.progress":             "Downloading %d/%d: %s",
+		"downloads.file_saved":           "Saved %s to %s",
+		"downloads.file_skipped":         "Skipped, already downloaded",
+		"downloads.file_failed":          "Failed: %v",
+		"downloads.done":                 "Downloads finished in %s",
+		"error.auth_hint":                "Check that SLACK_TOKEN, or token or tokenFile in the config file, holds a valid token, or run with --doctor to find the problem.",
+		"error.scope_hint":               "The token is missing a scope this needs. Run with --doctor to see which.",
+		"error.rate_limited":             "Slack is rate limiting requests, retrying in %s",
+		"error.network":                  "Can't reach Slack, retrying in %s",
+		"error.timeout_hint":             "Connection timed out — press r to retry",
+		"error.client_missing":           "Slack client not initialized",
+		"error.connect_timeout":          "Connecting to Slack timed out",
+		"error.fetch_timeout":            "Fetching messages timed out",
+		"error.token_read":               "Error reading the Slack token",
+		"error.no_workspace_token":       "No Slack token for workspace %q",
+		"error.no_token":                 "No Slack token: set SLACK_TOKEN, or token or tokenFile in the config file",
+		"error.sign_in":                  "Error signing in to Slack",
+		"error.channels":                 "Error getting channels",
+		"error.fetch_messages":           "Error fetching messages",
+		"error.fetch_older":              "Error fetching older messages",
+		"error.set_presence":             "Error setting presence",
+		"error.set_status":               "Error setting status",
+		"error.clear_status":             "Error clearing status",
+		"error.no_channel":               "No channel selected",
+		"error.send_message":             "Error sending message",
+		"error.send_reply":               "Error sending reply",
+		"error.edit_message":             "Error editing message",
+		"error.compose_mode":             "unknown compose mode %q",
+		"error.get_link":                 "Error getting link",
+		"error.open_browser":             "Error opening browser",
+		"error.add_reaction":             "Error adding reaction",
+		"error.remove_reaction":          "Error removing reaction",
+		"error.pin_message":              "Error pinning message",
+		"error.delete_message":           "Error deleting message",
+		"error.open_pager":               "Error opening pager",
+		"error.clipboard":                "Error copying to clipboard",
+		"error.channel_members":          "Error fetching channel members",
+		"error.open_file":                "Error opening file",
+		"error.load_thread":              "Error loading thread",
+		"error.mark_read":                "Error marking channel as read",
+		"error.change_presence":          "Error changing presence",
+		"error.scheduled_status":         "Error applying scheduled status",
+		"error.fetch_users":              "Error fetching users",
+		"error.fetch_mentions":           "Error fetching mentions",
+		"error.config":                   "Config",
+		"settings.unknown_timezone":      "unknown timezone %q",
+		"settings.already_newline":       "it's already the new line key",
+		"settings.already_send":          "it's already the send key",
+		"settings.bad_color":             "%q should be a hex color like #FFD966 or an ANSI color number",
+		"settings.not_number":            "%q is not a whole number",
+		"settings.too_small":             "must be at least %d",
+		"settings.key_required":          "a key is required",
+		"settings.bad_emoji":             "%q should look like :emoji_name:",
+		"messages.muted_shown":           "%d muted shown (M to hide)",
+		"messages.edited":                "(edited)",
+		"messages.since_last_seen":       "─── since you were last here ───",
+		"messages.history_start":         "─── beginning of the channel ───",
+		"pins.summary":                   "📌 %d pinned",
+		"pins.expand":                    "P to expand",
+		"messages.history_more":          "p loads older messages",
+		"messages.since_last_seen_above": "─── ↑ new since you were last here ───",
+		"messages.oldest_first":          "Oldest first (o to flip)",
+		"messages.newest_first":          "Newest first (o to flip)",
+		"messages.edited_detail":         "(edited %s by %s)",
+		"schedule.next":                  "Next status change: %s → %s",
+		"schedule.paused":                "Recurring status changes paused (%s to resume)",
+		"actions.title":                  "Message Actions",
+		"actions.react":                  "React",
+		"actions.react.d":                "Add an emoji reaction",
+		"actions.reply":                  "Reply in Thread",
+		"actions.reply.d":                "Reply in the message's thread",
+		"actions.quote":                  "Quote and Reply",
+		"actions.quote.d":                "Reply in the thread, quoting the message",
+		"actions.quote_channel":          "Quote in Channel",
+		"actions.quote_channel.d":        "Reply in the channel, quoting the message",
+		"actions.copy":                   "Copy Text",
+		"actions.copy.d":                 "Copy the message text",
+		"actions.code":                   "Copy Code Block",
+		"actions.code.d":                 "Copy just the code from a code block",
+		"actions.link":                   "Copy Link",
+		"actions.link.d":                 "Copy a link to the message",
+		"actions.pin":                    "Pin to Channel",
+		"actions.pin.d":                  "Pin the message to the channel",
+		"actions.edit":                   "Edit Message",
+		"actions.edit.d":                 "Edit the message text",
+		"actions.delete":                 "Delete Message",
+		"actions.delete.d":               "Delete the message",
+		"actions.thread":                 "Open Thread",
+		"actions.thread.d":               "Read the whole thread this message is part of",
+		"actions.pager":                  "Open in Pager",
+		"actions.pager.d":                "Read the full text in $PAGER or $EDITOR",
+		"actions.browser":                "Open in Browser",
+		"actions.browser.d":              "Open the message in Slack",
+		"actions.file":                   "Open File",
+		"actions.file.d":                 "Open an attached file in the browser",
+		"actions.jump":                   "Go to Linked Message",
+		"actions.jump.d":                 "Open the Slack message this one links to",
+		"reactions.title":                "Add Reaction",
+		"unreact.title":                  "Remove Which Reaction?",
+		"reactors.title":                 "Who Reacted",
+		"reactors.more":                  "+%d more",
+		"people.title":                   "People",
+		"settings.title":                 "Settings",
+		"settings.unset":                 "(not set)",
+		"settings.help":                  "enter: toggle, change, or edit • changes are saved to the config file",
+		"settings.save_failed":           "Couldn't save the config file: %v",
+		"mentions.title":                 "Needs Reply (%d)",
+		"codeblocks.title":               "Copy Which Block?",
+		"files.title":                    "Open Which File?",
+		"members.title":                  "Show Messages From",
+		"links.title":                    "Go to Which Link?",
+		"thread.title":                   "Thread in #%s",
+		"channels.title":                 "Channels",
+		"channels.title_presence":        "Channels • active people first",
+		"channels.draft":                 "✎ draft",
+		"channels.unread":                "(%d)",
+		"channels.unread_new":            "(new)",
+		"channels.help":                  "type to filter • tab: order by name or presence",
+		"channels.all":                   "All channels",
+		"channels.across":                "across %s",
+		"channels.activity":              "%s  %s msgs in %dh",
+		"roster.title":                   "Members of #%s",
+		"roster.title_count":             "Members of #%s (%d)",
+		"files.shared_by":                "shared by %s",
+		"files.hidden_by_limit":          "File hidden by the workspace's storage limit",
+		"files.deleted":                  "File deleted",
+		"files.restricted":               "File not accessible",
+		"compose.placeholder":            "Type a message...",
+		"compose.reply":                  "Reply to %s",
+		"compose.edit":                   "Edit message",
+		"compose.reply_channel":          "Reply in #%s",
+		"compose.new":                    "Message #%s",
+		"compose.unknown_channel":        "no channel named %s",
+		"compose.new_any":                "New message: start it with #channel to choose where it goes",
+		"compose.need_channel":           "start the message with #channel to choose where it goes",
+		"compose.drop_quote":             "ctrl+r: remove quote",
+		"compose.keys":                   "%s: send • %s: new line",
+		"compose.as_persona":             "Posting as %s (ctrl+p: post as yourself)",
+		"compose.as_self":                "Posting as yourself (ctrl+p: post as %s)",
+		"filter.placeholder":             "Type a channel name to filter...",
+		"search.placeholder":             "Search the loaded messages",
+		"search.matches":                 "Matching \"%s\": %d messages (/ to change, esc to clear)",
+		"toast.copied":                   "Message copied to clipboard",
+		"toast.link_copied":              "Link copied to clipboard",
+		"toast.code_copied":              "Code copied to clipboard",
+		"toast.thread_copied":            "Copied a thread of %d messages to the clipboard",
+		"thread.empty":                   "This thread has no messages to copy",
+		"thread.help":                    "y: copy as transcript",
+		"thread.transcript_file":         "[file: %s]",
+		"toast.file_opened":              "Opened %s",
+		"toast.status_set":               "Status set to %s",
+		"toast.status_cleared":           "Status cleared",
+		"toast.status_clears":            "clears at %s",
+		"toast.scheduled_status":         "Scheduled status set: %s",
+		"toast.schedule_paused":          "Recurring status changes paused",
+		"toast.schedule_resumed":         "Recurring status changes resumed",
+		"toast.opened":                   "Opened message in browser",
+		"toast.link_opened":              "Opened link in browser",
+		"toast.reply_sent":               "Reply sent",
+		"toast.sent_to":                  "Sent to #%s",
+		"toast.sent_at":                  "Message sent to #%s at %s",
+		"toast.channels_failed":          "Couldn't load %s",
+		"toast.quick_note_unknown":       "Quick note channel %q not found",
+		"toast.edited":                   "Message edited",
+		"toast.reacted":                  "Reacted with :%s:",
+		"toast.unreacted":                "Removed :%s:",
+		"toast.no_reactions":             "No reactions on this message",
+		"toast.no_files":                 "No files to open on this message",
+		"toast.nothing_to_mark":          "No messages to mark as read",
+		"toast.marked_read":              "Marked %s as read",
+		"toast.no_workspaces":            "No workspaces in the config file",
+		"toast.switching_workspace":      "Switching to %s…",
+		"toast.history_start":            "That's the beginning of the channel",
+		"toast.reaction_gone":            ":%s: was already removed",
+		"toast.pinned":                   "Message pinned",
+		"toast.deleted":                  "Message deleted",
+		"toast.subtypes_all":             "Showing all message types",
+		"toast.subtypes_filtered":        "Hiding filtered message types",
+		"toast.config_reloaded":          "Config reloaded",
+		"toast.config_reload_failed":     "Config not reloaded, keeping the current settings: %v",
+		"toast.persona_needs_bot":        "persona is set, but a custom name and icon need a bot token (xoxb-); posting as yourself",
+		"config.load_failed":             "couldn't read the config file, using the defaults: %v",
+		"config.unknown":                 "unknown %s %q, using %q",
+		"config.not_color":               "theme.%s %q isn't a color, using the default",
+		"config.idle_negative":           "awayAfterIdleMinutes can't be negative, turning auto-away off",
+		"config.timeout_negative":        "timeoutSeconds can't be negative, using %d",
+		"config.limit_range":             "%s must be between 1 and %d, using %d",
+		"config.history_negative":        "historyHours can't be negative, fetching messages of any age",
+		"config.scroll_negative":         "scrollLines must be a positive number of lines, using the default scrolling",
+		"config.not_positive":            "%s must be positive, using %d",
+		"config.refresh_negative":        "%s can't be negative, turning the periodic refresh off",
+		"config.same_keys":               "keys.sendMessage and keys.newLine are both %q, using %q and %q",
+		"config.timezone":                "unknown timezone %q, using the system timezone",
+		"config.schedule_rule":           "statusSchedule[%d]: %s, ignoring it",
+		"config.rule_time":               "invalid time %q, expected HH:MM",
+		"config.rule_day":                "unknown day %q",
+		"config.rule_presence":           "unknown presence %q",
+		"config.rule_empty":              "sets neither a presence nor a custom status",
+		"config.reaction":                "reaction %q isn't an emoji shortcode, ignoring it",
+		"config.status_presence":         "statuses[%d] has unknown presence %q, ignoring it",
+		"config.workspace_no_name":       "workspaces[%d] has no name, ignoring it",
+		"config.workspace_twice":         "workspace %q is listed twice, keeping the first",
+		"config.workspace_no_token":      "workspace %q has no token or tokenFile, ignoring it",
+		"config.preset_empty":            "presets[%d] has no description to send, ignoring it",
+		"config.emoji":                   "emoji %q: %s, ignoring it",
+		"config.emoji_empty":             "is empty",
+		"config.emoji_code_point":        "%q isn't a code point like U+1F680",
+		"config.emoji_utf8":              "isn't valid UTF-8",
+		"config.emoji_control":           "contains a control character",
+	},
+	"es": {
+		"app.initializing":               "Iniciando...",
+		"app.too_small":                  "Terminal demasiado pequeña (se necesitan al menos %dx%d)",
+		"app.loading":                    "Cargando...",
+		"app.error":                      "Error: %s",
+		"app.header":                     "Slack TUI - Sesión iniciada como: %s",
+		"app.footer":                     "q/ctrl+c: salir • esc: volver • ↑/↓/k/j: navegar • enter: seleccionar",
+		"messages.footer":                "esc: volver • ↑/↓: seleccionar • g/G: más antiguo/más reciente • r: actualizar • a: acciones",
+		"footer.thread":                  "esc: volver • ↑/↓: desplazar • y: copiar hilo",
+		"footer.compose":                 "%s: enviar • esc: cancelar",
+		"footer.channels":                "escribe para filtrar • ↑/↓: navegar • enter: abrir • esc: volver",
+		"footer.status":                  "esc: volver • ↑/↓/k/j: navegar • enter: cambiar estado",
+		"footer.list":                    "esc: volver • ↑/↓/k/j: navegar • enter: seleccionar",
+		"footer.back":                    "esc: volver",
+		"channel_info.topic":             "Tema",
+		"channel_info.purpose":           "Propósito",
+		"channel_info.none":              "Sin definir",
+		"channel_info.member":            "%d miembro",
+		"channel_info.members":           "%d miembros",
+		"channel_info.archived":          "Archivado",
+		"channel_info.not_member":        "No eres miembro de este canal",
+		"channel_info.no_access":         "No se pueden leer los detalles de este canal: es privado y no estás en él",
+		"channel_info.failed":            "Error al obtener los detalles del canal: %v",
+		"menu.quick_actions":             "Acciones rápidas",
+		"menu.view_messages":             "Ver mensajes",
+		"menu.view_messages.d":           "Ver los mensajes recientes de Slack",
+		"menu.channels":                  "Explorar canales",
+		"menu.channels.d":                "Elegir un canal para leer",
+		"menu.set_status":                "Cambiar estado",
+		"menu.set_status.d":              "Cambiar tu estado de Slack",
+		"menu.send_preset":               "Enviar mensaje predefinido",
+		"menu.send_preset.d":             "Enviar un mensaje preconfigurado",
+		"menu.compose":                   "Redactar mensaje",
+		"menu.compose.d":                 "Escribir un mensaje al canal seleccionado",
+		"menu.people":                    "Personas",
+		"menu.people.d":                  "Ver los miembros del espacio de trabajo",
+		"menu.settings":                  "Ajustes",
+		"menu.settings.d":                "Cambiar los ajustes de la aplicación",
+		"menu.workspaces":                "Cambiar de espacio de trabajo",
+		"menu.workspaces.d":              "Conectarse a otro de tus espacios de trabajo",
+		"menu.mentions":                  "Pendientes de respuesta",
+		"menu.mentions.d":                "Menciones que aún no has respondido",
+		"menu.quit":                      "Salir",
+		"menu.quit.d":                    "Cerrar la aplicación",
+		"quit.confirm":                   "¿Salir? (y/n)",
+		"presets.title":                  "Mensajes predefinidos",
+		"status.title":                   "Cambiar estado",
+		"statusclear.title":              "Borrar el estado tras",
+		"statusclear.30m":                "30 minutos",
+		"statusclear.1h":                 "1 hora",
+		"statusclear.4h":                 "4 horas",
+		"statusclear.today":              "Hoy",
+		"statusclear.never":              "No borrar",
+		"workspaces.title":               "Cambiar de espacio de trabajo",
+		"workspaces.default":             "Predeterminado",
+		"workspaces.default.d":           "El espacio de trabajo de SLACK_TOKEN o del token de la configuración",
+		"workspaces.other.d":             "Cambiar a este espacio de trabajo",
+		"workspaces.active.d":            "Conectado ahora",
+		"status.active":                  "Activo",
+		"status.active.d":                "Cambiar tu estado a activo",
+		"status.away":                    "Ausente",
+		"status.away.d":                  "Cambiar tu estado a ausente",
+		"status.dnd":                     "No molestar",
+		"status.dnd.d":                   "Cambiar tu estado a no molestar",
+		"status.unknown":                 "Desconocido",
+		"status.cleared":                 "Sin estado",
+		"status.clear":                   "Borrar estado",
+		"status.clear.d":                 "Quitar tu estado personalizado y volver a la presencia automática",
+		"status.idle_away":               "Ausente (inactivo)",
+		"messages.empty":                 "No se encontraron mensajes.",
+		"messages.in_channel":            "en",
+		"messages.unknown":               "Usuario desconocido",
+		"messages.from_user":             "Mostrando solo mensajes de %s (f para quitar)",
+		"messages.muted_hidden":          "%d ocultos (M para mostrar)",
+		"messages.last_activity":         "#%s: última actividad %s (%s)",
+		"messages.thread_reply":          "(respuesta en hilo)",
+		"messages.reply":                 "%d respuesta",
+		"messages.replies":               "%d respuestas",
+		"details.sent":                   "Enviado %s",
+		"details.edited":                 "Editado %s por %s",
+		"details.ts":                     "ts %s • canal %s • usuario %s",
+		"details.thread_ts":              "• hilo %s",
+		"details.link":                   "Enlace %s",
+		"messages.thread_reply_to":       "(respuesta en hilo a %s)",
+		"layout.too_narrow":              "Vista dividida guardada; aparecerá cuando la ventana sea más ancha",
+		"focus.on":                       "Modo concentración: pulsa %s para recuperar los menús",
+		"banner.session":                 "Conectado a %s como @%s • %d canales • %s",
+		"banner.live":                    "actualizaciones en vivo",
+		"banner.user_token":              "token de usuario",
+		"banner.bot_token":               "token de bot",
+		"app.api_warnings":               "⚠ %d avisos de la API",
+		"app.reconnecting":               "reconectando…",
+		"date.today":                     "hoy",
+		"date.yesterday":                 "ayer",
+		"date.tomorrow":                  "mañana",
+		"date.just_now":                  "ahora mismo",
+		"date.minute":                    "%d minuto",
+		"date.minutes":                   "%d minutos",
+		"date.hour":                      "%d hora",
+		"date.hours":                     "%d horas",
+		"date.day":                       "%d día",
+		"date.days":                      "%d días",
+		"date.ago":                       "hace %s",
+		"date.in":                        "dentro de %s",
+		"toast.users_loading":            "Cargando el directorio de usuarios...",
+		"toast.users_loaded":             "%d usuarios cargados",
+		"toast.users_failed":             "Error al cargar el directorio de usuarios: %v",
+		"downloads.title":                "Descargas: %d guardados, %d omitidos, %d fallidos",
+		"downloads.busy":                 "Ya hay una descarga en curso",
+		"downloads.failed_start":         "No se pudo iniciar la descarga: %v",
+		"downloads.none":                 "No hay archivos que descargar en #%s",
+		"downloads.collecting":           "Buscando archivos...",
+		"downloads.progress":             "Descargando %d/%d: %s",
+		"downloads.file_saved":           "Guardado %s en %s",
+		"downloads.file_skipped":         "Omitido, ya descargado",
+		"downloads.file_failed":          "Error: %v",
+		"downloads.done":                 "Descargas terminadas en %s",
+		"error.auth_hint":                "Comprueba que SLACK_TOKEN, o token o tokenFile en el archivo de configuración, contiene un token válido, o ejecuta con --doctor para encontrar el problema.",
+		"error.scope_hint":               "Al token le falta un permiso necesario. Ejecuta con --doctor para ver cuál.",
+		"error.rate_limited":             "Slack está limitando las peticiones, reintentando en %s",
+		"error.network":                  "No se puede conectar con Slack, reintentando en %s",
+		"error.timeout_hint":             "Se agotó el tiempo de conexión — pulsa r para reintentar",
+		"error.client_missing":           "El cliente de Slack no está iniciado",
+		"error.connect_timeout":          "Se agotó el tiempo al conectar con Slack",
+		"error.fetch_timeout":            "Se agotó el tiempo al cargar los mensajes",
+		"error.token_read":               "Error al leer el token de Slack",
+		"error.no_workspace_token":       "No hay token de Slack para el espacio de trabajo %q",
+		"error.no_token":                 "No hay token de Slack: define SLACK_TOKEN, o token o tokenFile en el archivo de configuración",
+		"error.sign_in":                  "Error al iniciar sesión en Slack",
+		"error.channels":                 "Error al obtener los canales",
+		"error.fetch_messages":           "Error al cargar los mensajes",
+		"error.fetch_older":              "Error al cargar mensajes anteriores",
+		"error.set_presence":             "Error al cambiar la presencia",
+		"error.set_status":               "Error al cambiar el estado",
+		"error.clear_status":             "Error al borrar el estado",
+		"error.no_channel":               "No hay ningún canal seleccionado",
+		"error.send_message":             "Error al enviar el mensaje",
+		"error.send_reply":               "Error al enviar la respuesta",
+		"error.edit_message":             "Error al editar el mensaje",
+		"error.compose_mode":             "modo de redacción desconocido %q",
+		"error.get_link":                 "Error al obtener el enlace",
+		"error.open_browser":             "Error al abrir el navegador",
+		"error.add_reaction":             "Error al añadir la reacción",
+		"error.remove_reaction":          "Error al quitar la reacción",
+		"error.pin_message":              "Error al fijar el mensaje",
+		"error.delete_message":           "Error al eliminar el mensaje",
+		"error.open_pager":               "Error al abrir el paginador",
+		"error.clipboard":                "Error al copiar al portapapeles",
+		"error.channel_members":          "Error al cargar los miembros del canal",
+		"error.open_file":                "Error al abrir el archivo",
+		"error.load_thread":              "Error al cargar el hilo",
+		"error.mark_read":                "Error al marcar el canal como leído",
+		"error.change_presence":          "Error al cambiar la presencia",
+		"error.scheduled_status":         "Error al aplicar el estado programado",
+		"error.fetch_users":              "Error al cargar los usuarios",
+		"error.fetch_mentions":           "Error al cargar las menciones",
+		"error.config":                   "Configuración",
+		"settings.unknown_timezone":      "zona horaria desconocida %q",
+		"settings.already_newline":       "ya es la tecla de nueva línea",
+		"settings.already_send":          "ya es la tecla de enviar",
+		"settings.bad_color":             "%q debe ser un color hexadecimal como #FFD966 o un número de color ANSI",
+		"settings.not_number":            "%q no es un número entero",
+		"settings.too_small":             "debe ser al menos %d",
+		"settings.key_required":          "hace falta una tecla",
+		"settings.bad_emoji":             "%q debe tener la forma :nombre_emoji:",
+		"messages.muted_shown":           "%d silenciados visibles (M para ocultar)",
+		"messages.edited":                "(editado)",
+		"messages.since_last_seen":       "─── desde tu última visita ───",
+		"messages.history_start":         "─── principio del canal ───",
+		"pins.summary":                   "📌 %d fijados",
+		"pins.expand":                    "P para desplegar",
+		"messages.history_more":          "p carga mensajes anteriores",
+		"messages.since_last_seen_above": "─── ↑ nuevos desde tu última visita ───",
+		"messages.oldest_first":          "Más antiguos primero (o para invertir)",
+		"messages.newest_first":          "Más recientes primero (o para invertir)",
+		"messages.edited_detail":         "(editado %s por %s)",
+		"schedule.next":                  "Próximo cambio de estado: %s → %s",
+		"schedule.paused":                "Cambios de estado recurrentes en pausa (%s para reanudar)",
+		"actions.title":                  "Acciones del mensaje",
+		"actions.react":                  "Reaccionar",
+		"actions.react.d":                "Añadir una reacción con emoji",
+		"actions.reply":                  "Responder en hilo",
+		"actions.reply.d":                "Responder en el hilo del mensaje",
+		"actions.quote":                  "Citar y responder",
+		"actions.quote.d":                "Responder en el hilo citando el mensaje",
+		"actions.quote_channel":          "Citar en el canal",
+		"actions.quote_channel.d":        "Responder en el canal citando el mensaje",
+		"actions.copy":                   "Copiar texto",
+		"actions.copy.d":                 "Copiar el texto del mensaje",
+		"actions.code":                   "Copiar bloque de código",
+		"actions.code.d":                 "Copiar solo el código de un bloque",
+		"actions.link":                   "Copiar enlace",
+		"actions.link.d":                 "Copiar un enlace al mensaje",
+		"actions.pin":                    "Fijar en el canal",
+		"actions.pin.d":                  "Fijar el mensaje en el canal",
+		"actions.edit":                   "Editar mensaje",
+		"actions.edit.d":                 "Editar el texto del mensaje",
+		"actions.delete":                 "Eliminar mensaje",
+		"actions.delete.d":               "Eliminar el mensaje",
+		"actions.thread":                 "Abrir hilo",
+		"actions.thread.d":               "Leer el hilo completo del que forma parte este mensaje",
+		"actions.pager":                  "Abrir en el paginador",
+		"actions.pager.d":                "Leer el texto completo en $PAGER o $EDITOR",
+		"actions.browser":                "Abrir en el navegador",
+		"actions.browser.d":              "Abrir el mensaje en Slack",
+		"actions.file":                   "Abrir archivo",
+		"actions.file.d":                 "Abrir un archivo adjunto en el navegador",
+		"actions.jump":                   "Ir al mensaje enlazado",
+		"actions.jump.d":                 "Abrir el mensaje de Slack al que enlaza este",
+		"reactions.title":                "Añadir reacción",
+		"unreact.title":                  "¿Qué reacción quitar?",
+		"reactors.title":                 "Quién reaccionó",
+		"reactors.more":                  "+%d más",
+		"people.title":                   "Personas",
+		"settings.title":                 "Ajustes",
+		"settings.unset":                 "(sin definir)",
+		"settings.help":                  "enter: activar, cambiar o editar • los cambios se guardan en el archivo de configuración",
+		"settings.save_failed":           "No se pudo guardar el archivo de configuración: %v",
+		"mentions.title":                 "Pendientes de respuesta (%d)",
+		"codeblocks.title":               "¿Qué bloque copiar?",
+		"files.title":                    "¿Qué archivo abrir?",
+		"members.title":                  "Mostrar mensajes de",
+		"links.title":                    "¿A qué enlace ir?",
+		"thread.title":                   "Hilo en #%s",
+		"channels.title":                 "Canales",
+		"channels.title_presence":        "Canales • personas activas primero",
+		"channels.draft":                 "✎ borrador",
+		"channels.unread":                "(%d)",
+		"channels.unread_new":            "(nuevo)",
+		"channels.help":                  "escribe para filtrar • tab: ordenar por nombre o presencia",
+		"channels.all":                   "Todos los canales",
+		"channels.across":                "en %s",
+		"channels.activity":              "%s  %s mensajes en %dh",
+		"roster.title":                   "Miembros de #%s",
+		"roster.title_count":             "Miembros de #%s (%d)",
+		"files.shared_by":                "compartido por %s",
+		"files.hidden_by_limit":          "Archivo oculto por el límite de almacenamiento",
+		"files.deleted":                  "Archivo eliminado",
+		"files.restricted":               "Archivo no accesible",
+		"compose.placeholder":            "Escribe un mensaje...",
+		"compose.keys":                   "%s: enviar • %s: nueva línea",
+		"compose.reply":                  "Responder a %s",
+		"compose.edit":                   "Editar mensaje",
+		"compose.reply_channel":          "Responder en #%s",
+		"compose.new":                    "Mensaje a #%s",
+		"compose.unknown_channel":        "no hay ningún canal llamado %s",
+		"compose.new_any":                "Mensaje nuevo: empiézalo con #canal para elegir a dónde va",
+		"compose.need_channel":           "empieza el mensaje con #canal para elegir a dónde va",
+		"compose.drop_quote":             "ctrl+r: quitar cita",
+		"compose.as_persona":             "Publicando como %s (ctrl+p: publicar como tú)",
+		"compose.as_self":                "Publicando como tú (ctrl+p: publicar como %s)",
+		"filter.placeholder":             "Escribe el nombre de un canal para filtrar...",
+		"search.placeholder":             "Buscar en los mensajes cargados",
+		"search.matches":                 "Coincidencias con \"%s\": %d mensajes (/ para cambiar, esc para quitar)",
+		"toast.copied":                   "Mensaje copiado al portapapeles",
+		"toast.link_copied":              "Enlace copiado al portapapeles",
+		"toast.code_copied":              "Código copiado al portapapeles",
+		"toast.thread_copied":            "Hilo de %d mensajes copiado al portapapeles",
+		"thread.empty":                   "Este hilo no tiene mensajes que copiar",
+		"thread.help":                    "y: copiar como transcripción",
+		"thread.transcript_file":         "[archivo: %s]",
+		"toast.file_opened":              "Se abrió %s",
+		"toast.status_set":               "Estado cambiado a %s",
+		"toast.status_cleared":           "Estado borrado",
+		"toast.status_clears":            "se borra a las %s",
+		"toast.scheduled_status":         "Estado programado aplicado: %s",
+		"toast.schedule_paused":          "Cambios de estado recurrentes en pausa",
+		"toast.schedule_resumed":         "Cambios de estado recurrentes reanudados",
+		"toast.opened":                   "Mensaje abierto en el navegador",
+		"toast.link_opened":              "Enlace abierto en el navegador",
+		"toast.reply_sent":               "Respuesta enviada",
+		"toast.sent_to":                  "Enviado a #%s",
+		"toast.sent_at":                  "Mensaje enviado a #%s a las %s",
+		"toast.channels_failed":          "No se pudo cargar %s",
+		"toast.quick_note_unknown":       "No se encontró el canal %q para la nota rápida",
+		"toast.edited":                   "Mensaje editado",
+		"toast.reacted":                  "Reaccionaste con :%s:",
+		"toast.unreacted":                "Se quitó :%s:",
+		"toast.no_reactions":             "Este mensaje no tiene reacciones",
+		"toast.no_files":                 "Este mensaje no tiene archivos que abrir",
+		"toast.nothing_to_mark":          "No hay mensajes que marcar como leídos",
+		"toast.marked_read":              "%s marcado como leído",
+		"toast.no_workspaces":            "No hay espacios de trabajo en la configuración",
+		"toast.switching_workspace":      "Cambiando a %s…",
+		"toast.history_start":            "Es el principio del canal",
+		"toast.reaction_gone":            ":%s: ya se había quitado",
+		"toast.pinned":                   "Mensaje fijado",
+		"toast.deleted":                  "Mensaje eliminado",
+		"toast.subtypes_all":             "Mostrando todos los tipos de mensaje",
+		"toast.subtypes_filtered":        "Ocultando los tipos de mensaje filtrados",
+		"toast.config_reloaded":          "Configuración recargada",
+		"toast.config_reload_failed":     "No se recargó la configuración, se mantienen los ajustes actuales: %v",
+		"toast.persona_needs_bot":        "persona está configurado, pero un nombre e icono propios requieren un token de bot (xoxb-); se publicará como tú",
+		"config.load_failed":             "no se pudo leer el archivo de configuración, se usan los valores predeterminados: %v",
+		"config.unknown":                 "%s %q desconocido, se usa %q",
+		"config.not_color":               "theme.%s %q no es un color, se usa el predeterminado",
+		"config.idle_negative":           "awayAfterIdleMinutes no puede ser negativo, se desactiva la ausencia automática",
+		"config.timeout_negative":        "timeoutSeconds no puede ser negativo, se usa %d",
+		"config.limit_range":             "%s debe estar entre 1 y %d, se usa %d",
+		"config.history_negative":        "historyHours no puede ser negativo, se obtienen mensajes de cualquier antigüedad",
+		"config.scroll_negative":         "scrollLines debe ser un número positivo de líneas, se usa el desplazamiento predeterminado",
+		"config.not_positive":            "%s debe ser positivo, se usa %d",
+		"config.refresh_negative":        "%s no puede ser negativo, se desactiva la actualización periódica",
+		"config.same_keys":               "keys.sendMessage y keys.newLine son ambos %q, se usan %q y %q",
+		"config.timezone":                "zona horaria %q desconocida, se usa la del sistema",
+		"config.schedule_rule":           "statusSchedule[%d]: %s, se ignora",
+		"config.rule_time":               "hora %q no válida, se esperaba HH:MM",
+		"config.rule_day":                "día %q desconocido",
+		"config.rule_presence":           "presencia %q desconocida",
+		"config.rule_empty":              "no establece ni una presencia ni un estado personalizado",
+		"config.reaction":                "la reacción %q no es un código de emoji, se ignora",
+		"config.status_presence":         "statuses[%d] tiene una presencia %q desconocida, se ignora",
+		"config.workspace_no_name":       "workspaces[%d] no tiene nombre, se ignora",
+		"config.workspace_twice":         "el espacio de trabajo %q aparece dos veces, se mantiene el primero",
+		"config.workspace_no_token":      "el espacio de trabajo %q no tiene token ni tokenFile, se ignora",
+		"config.preset_empty":            "presets[%d] no tiene descripción que enviar, se ignora",
+		"config.emoji":                   "emoji %q: %s, se ignora",
+		"config.emoji_empty":             "está vacío",
+		"config.emoji_code_point":        "%q no es un punto de código como U+1F680",
+		"config.emoji_utf8":              "no es UTF-8 válido",
+		"config.emoji_control":           "contiene un carácter de control",
+	},
+}
+
+// Set the UI locale, falling back to English for unknown locales
+func setLocale(locale string) {
+	if _, ok := translations[locale]; ok {
+		activeLocale = locale
+	} else {
+		activeLocale = defaultLocale
+	}
+}
+
+// Look up a UI string in the active locale, falling back to English and then the key itself
+func tr(key string) string {
+	if s, ok := translations[activeLocale][key]; ok {
+		return s
+	}
+	if s, ok := translations[defaultLocale][key]; ok {
+		return s
+	}
+	return key
+}
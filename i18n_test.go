@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestTranslationsComplete(t *testing.T) {
+	for locale, strings := range translations {
+		if locale == defaultLocale {
+			continue
+		}
+		for key := range translations[defaultLocale] {
+			if _, ok := strings[key]; !ok {
+				t.Errorf("%s is missing %q", locale, key)
+			}
+		}
+	}
+}
+
+func TestErrorsTranslated(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	setLocale("es")
+	t.Cleanup(func() { setLocale(defaultLocale) })
+
+	m.slackClient = nil
+	msg, ok := m.sendPresetMessage("hola").(genericErrMsg)
+	if !ok || msg.text != translations["es"]["error.client_missing"] {
+		t.Errorf("sendPresetMessage = %+v, want the Spanish error", msg)
+	}
+}
+
+func TestConfigWarningsTranslated(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Locale = "es"
+	cfg.ChannelSort = "sideways"
+	t.Cleanup(func() { setLocale(defaultLocale) })
+
+	m := initialModel(cfg, State{})
+	want := `channelSort "sideways" desconocido, se usa "name"`
+	if len(m.configWarnings) != 1 || m.configWarnings[0] != want {
+		t.Errorf("configWarnings = %q, want [%q]", m.configWarnings, want)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How often to check whether the user has gone idle
+const idleCheckInterval = 30 * time.Second
+
+type idleTickMsg struct {
+	seq int
+}
+
+type autoAwayMsg struct {
+	away bool
+	err  error
+}
+
+// Wait for the next idle check, if auto-away is on
+func (m Model) idleTick() tea.Cmd {
+	if m.config.AwayAfterIdleMinutes <= 0 {
+		return nil
+	}
+	seq := m.idleSeq
+	return tea.Tick(idleCheckInterval, func(time.Time) tea.Msg {
+		return idleTickMsg{seq: seq}
+	})
+}
+
+// Start the idle checks over, dropping any already waiting
+func (m *Model) restartIdleTick() tea.Cmd {
+	m.idleSeq++
+	return m.idleTick()
+}
+
+// Go away once there's been no keyboard input for the configured time.
+// Only an active user is switched, so a chosen away or DND status is left alone.
+func (m *Model) handleIdleTick(msg idleTickMsg) tea.Cmd {
+	if msg.seq != m.idleSeq {
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.idleTick()}
+
+	idle := time.Duration(m.config.AwayAfterIdleMinutes) * time.Minute
+	if !m.autoAway && m.slackClient != nil && (m.userStatus == statusActive || m.userStatus == statusCleared) && time.Since(m.lastInput) >= idle {
+		m.autoAway = true
+		cmds = append(cmds, func() tea.Msg {
+			return m.setAutoAway(true)
+		})
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Note keyboard activity, coming back from auto-away if it had kicked in
+func (m *Model) noteInput() tea.Cmd {
+	m.lastInput = time.Now()
+	if !m.autoAway {
+		return nil
+	}
+
+	m.autoAway = false
+	return func() tea.Msg {
+		return m.setAutoAway(false)
+	}
+}
+
+// Switch presence for auto-away, leaving the custom status untouched
+func (m *Model) setAutoAway(away bool) tea.Msg {
+	presence, _ := slackPresence(statusActive)
+	if away {
+		presence, _ = slackPresence(statusAway)
+	}
+	return autoAwayMsg{away: away, err: m.slackClient.SetUserPresence(presence)}
+}
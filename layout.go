@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Layouts of the messages page
+const (
+	layoutSingle = "single"
+	layoutSplit  = "split"
+)
+
+const (
+	// Share of the width the channel pane takes in the split layout
+	defaultSplitRatio = 0.25
+	minSplitRatio     = 0.15
+	maxSplitRatio     = 0.5
+	splitRatioStep    = 0.05
+
+	// Narrower terminals fall back to a single column
+	minSplitWidth = 80
+
+	// Below this size the chrome leaves no room to draw anything legible
+	minTerminalWidth  = 40
+	minTerminalHeight = 12
+)
+
+// Whether the messages page is showing the channel pane beside the messages
+func (m Model) splitActive() bool {
+	return m.state.Layout == layoutSplit && m.width >= minSplitWidth && !m.config.FocusMode
+}
+
+// Whether the page on screen is showing only the message content, without the chrome
+func (m Model) focused() bool {
+	return m.config.FocusMode && (m.currentPage == pageMessages || m.currentPage == pageThread)
+}
+
+// Whether the terminal is too small for the layout, so a notice is shown instead
+func (m Model) tooSmall() bool {
+	return m.width < minTerminalWidth || m.height < minTerminalHeight
+}
+
+// Space the pages with chrome leave for their content
+func (m Model) chromeContentSize() (int, int) {
+	return max(m.width-4, 0), max(m.height-headerHeight-footerHeight, 0)
+}
+
+// Width of the channel pane, including its border
+func (m Model) channelPaneWidth() int {
+	if !m.splitActive() {
+		return 0
+	}
+	return int(float64(m.width-4) * m.splitRatio())
+}
+
+// The saved split ratio, kept within bounds
+func (m Model) splitRatio() float64 {
+	ratio := m.state.SplitRatio
+	if ratio == 0 {
+		return defaultSplitRatio
+	}
+	return min(max(ratio, minSplitRatio), maxSplitRatio)
+}
+
+// Size the viewport to what the layout leaves for it. In focus mode it takes
+// the whole terminal and drops its own border too.
+func (m *Model) applyLayout() {
+	if m.config.FocusMode {
+		m.viewport.Width, m.viewport.Height = m.width, m.height
+	} else {
+		width, height := m.chromeContentSize()
+		m.viewport.Width, m.viewport.Height = max(width-m.channelPaneWidth(), 0), height
+	}
+
+	border := !m.config.FocusMode
+	m.viewport.Style = m.viewport.Style.BorderTop(border).BorderRight(border).BorderBottom(border).BorderLeft(border)
+	m.refreshMessages()
+
+	// Re-wrap an open thread to the new width
+	if m.currentPage == pageThread {
+		m.showThread(threadMsg{link: m.threadLink, messages: m.thread})
+	}
+}
+
+// Width message text wraps at: the viewport's, inside its border, and never
+// below zero in a terminal too small to fit the border
+func (m Model) messageWidth() int {
+	return max(m.viewport.Width-m.viewport.Style.GetHorizontalFrameSize(), 0)
+}
+
+// Hide or bring back the chrome around the messages, saving the choice to the config file
+func (m *Model) toggleFocusMode() tea.Cmd {
+	m.config.FocusMode = !m.config.FocusMode
+	m.applyLayout()
+
+	if err := writeConfigValue("focusMode", strconv.FormatBool(m.config.FocusMode), settingBool); err != nil {
+		return m.showToast(fmt.Sprintf(tr("settings.save_failed"), err), true)
+	}
+	if m.config.FocusMode {
+		return m.showToast(fmt.Sprintf(tr("focus.on"), m.config.Keys.FocusMode), false)
+	}
+	return nil
+}
+
+// Switch between the single-column and split layouts
+func (m *Model) toggleLayout() tea.Cmd {
+	if m.state.Layout == layoutSplit {
+		m.state.Layout = layoutSingle
+	} else {
+		m.state.Layout = layoutSplit
+	}
+	m.applyLayout()
+
+	if m.state.Layout == layoutSplit && m.width < minSplitWidth {
+		return m.showToast(tr("layout.too_narrow"), true)
+	}
+	return nil
+}
+
+// Widen or narrow the channel pane
+func (m *Model) resizeSplit(delta float64) {
+	if !m.splitActive() {
+		return
+	}
+	m.state.SplitRatio = min(max(m.splitRatio()+delta, minSplitRatio), maxSplitRatio)
+	m.applyLayout()
+}
+
+// Show the channel before or after the selected one, with "all channels" at the top
+func (m *Model) stepChannel(delta int) tea.Cmd {
+	ids := []string{""}
+	for _, ch := range m.channels {
+		ids = append(ids, ch.ID)
+	}
+
+	current := 0
+	for i, id := range ids {
+		if id == m.selectedChannelID {
+			current = i
+		}
+	}
+	next := (current + delta + len(ids)) % len(ids)
+	return m.showChannel(ids[next])
+}
+
+// Render the channel pane, scrolled to keep the selected channel in view
+func (m Model) channelPane() string {
+	width := m.channelPaneWidth() - m.viewport.Style.GetHorizontalFrameSize()
+	height := m.viewport.Height - m.viewport.Style.GetVerticalFrameSize()
+
+	names := []string{tr("channels.all")}
+	selected := 0
+	for i, ch := range m.channels {
+		names = append(names, m.channelLabel(ch.Name))
+		if ch.ID == m.selectedChannelID {
+			selected = i + 1
+		}
+	}
+
+	start := 0
+	if selected >= height {
+		start = selected - height + 1
+	}
+
+	var lines []string
+	for i := start; i < len(names) && i < start+height; i++ {
+		name := truncate(names[i], width-2)
+		if i == selected {
+			lines = append(lines, selectedMarkerStyle.Render("▌ ")+selectedChannelStyle.Render(name))
+		} else {
+			lines = append(lines, "  "+name)
+		}
+	}
+
+	return m.viewport.Style.
+		Width(width).
+		Height(height).
+		Render(strings.Join(lines, "\n"))
+}
+
+// Cut text to fit a width, marking the cut with an ellipsis
+func truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
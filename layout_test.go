@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestMessageWrapsAtWords(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	updated, _ := m.handleMsg(tea.WindowSizeMsg{Width: 50, Height: 30})
+	m = updated.(Model)
+
+	words := strings.Fields("the deploy went out at noon and every service came back healthy except the billing worker which needed a restart")
+	rendered := m.renderMessage(SlackMessage{ChannelID: "C1", Channel: "general", User: "alice", Content: strings.Join(words, " ")}, false)
+
+	var text []string
+	for _, line := range strings.Split(rendered, "\n") {
+		if width := lipgloss.Width(line); width > m.messageWidth() {
+			t.Errorf("line %q is %d wide, past the %d to wrap at", line, width, m.messageWidth())
+		}
+		if strings.Contains(line, "billing") || strings.Contains(line, "deploy") || strings.Contains(line, "healthy") {
+			text = append(text, line)
+		}
+	}
+	if len(text) < 2 {
+		t.Fatalf("the message wasn't split across lines:\n%s", rendered)
+	}
+
+	// Every word is kept whole on one line
+	joined := strings.Fields(strings.Join(strings.Split(rendered, "\n"), " "))
+	for _, word := range words {
+		if !slices.Contains(joined, word) {
+			t.Errorf("%q was split across lines:\n%s", word, rendered)
+		}
+	}
+}
+
+func TestTinyTerminal(t *testing.T) {
+	for _, layout := range []string{layoutSingle, layoutSplit} {
+		for _, size := range []tea.WindowSizeMsg{{Width: 0, Height: 0}, {Width: 1, Height: 1}, {Width: 12, Height: 4}, {Width: minTerminalWidth - 1, Height: minTerminalHeight - 1}} {
+			m := newTestModel(&fakeSlack{})
+			m.state.Layout = layout
+			m.currentPage = pageMessages
+			m.selectedChannelID = "C1"
+			m.messages = []SlackMessage{{ChannelID: "C1", Channel: "general", User: "alice", Content: "hello"}}
+
+			updated, _ := m.handleMsg(size)
+			m = updated.(Model)
+
+			chromeWidth, chromeHeight := m.chromeContentSize()
+			dims := map[string]int{
+				"viewport width":      m.viewport.Width,
+				"viewport height":     m.viewport.Height,
+				"message width":       m.messageWidth(),
+				"content width":       chromeWidth,
+				"content height":      chromeHeight,
+				"menu width":          m.quickActions.Width(),
+				"menu height":         m.quickActions.Height(),
+				"channel list height": m.channelList.Height(),
+				"compose width":       m.composeInput.Width(),
+			}
+			for name, dim := range dims {
+				if dim < 0 {
+					t.Errorf("%s layout at %dx%d: %s = %d", layout, size.Width, size.Height, name, dim)
+				}
+			}
+
+			if size.Width > 0 && !strings.Contains(m.View(), fmt.Sprintf(tr("app.too_small"), minTerminalWidth, minTerminalHeight)) {
+				t.Errorf("%s layout at %dx%d doesn't ask for a bigger terminal", layout, size.Width, size.Height)
+			}
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+type liveMessageMsg struct {
+	message SlackMessage
+
+	// Connection the message came in on, to tell it from one since switched away from
+	rtm *slack.RTM
+}
+
+type liveFlushMsg struct{}
+
+// States of the real-time connection, shown in the header
+const (
+	liveConnected    = "connected"
+	liveReconnecting = "reconnecting"
+)
+
+type liveStateMsg struct {
+	state string
+	rtm   *slack.RTM
+}
+
+// Wait for the next new message from the real-time connection
+func (m *Model) waitForLiveMessage() tea.Cmd {
+	if m.rtm == nil {
+		return nil
+	}
+
+	rtm := m.rtm
+	return func() tea.Msg {
+		for event := range rtm.IncomingEvents {
+			// Stop listening once the connection is closed on purpose, as when switching
+			// workspaces; otherwise note it dropping and coming back
+			switch data := event.Data.(type) {
+			case *slack.DisconnectedEvent:
+				if data.Intentional {
+					return nil
+				}
+				return liveStateMsg{state: liveReconnecting, rtm: rtm}
+			case *slack.ConnectingEvent, *slack.ConnectionErrorEvent:
+				return liveStateMsg{state: liveReconnecting, rtm: rtm}
+			case *slack.ConnectedEvent:
+				return liveStateMsg{state: liveConnected, rtm: rtm}
+			}
+
+			ev, ok := event.Data.(*slack.MessageEvent)
+			if !ok {
+				continue
+			}
+
+			// Only new top-level messages; edits, deletions, and thread replies aren't shown live
+			if ev.SubType == "message_changed" || ev.SubType == "message_deleted" || ev.SubType == "message_replied" {
+				continue
+			}
+			if ev.ThreadTimestamp != "" && ev.ThreadTimestamp != ev.Timestamp {
+				continue
+			}
+			if m.isHiddenSubtype(ev.SubType) {
+				continue
+			}
+			m.cacheMentionedUsers(ev.Text)
+
+			return liveMessageMsg{message: SlackMessage{
+				User:      m.messageAuthor(ev.Msg),
+				UserID:    ev.User,
+				Content:   ev.Text,
+				Channel:   m.channelName(ev.Channel),
+				ChannelID: ev.Channel,
+				Timestamp: ev.Timestamp,
+				Time:      parseSlackTimestamp(ev.Timestamp),
+				Files:     ev.Files,
+				Edited:    ev.Edited,
+				IsStarred: ev.IsStarred,
+				ThreadTS:  ev.ThreadTimestamp,
+			}, rtm: rtm}
+		}
+		return nil
+	}
+}
+
+// Show the connection's new state and keep listening, catching up on what was
+// missed once it's back
+func (m *Model) handleLiveState(msg liveStateMsg) tea.Cmd {
+	if msg.rtm != m.rtm {
+		return nil
+	}
+	reconnected := m.liveState == liveReconnecting && msg.state == liveConnected
+	m.liveState = msg.state
+
+	cmds := []tea.Cmd{m.waitForLiveMessage()}
+	if reconnected && m.currentPage == pageMessages {
+		cmds = append(cmds, m.fetchMessages)
+	}
+	return tea.Batch(cmds...)
+}
+
+// Buffer a live message, re-rendering at most once per interval however fast they arrive
+func (m *Model) handleLiveMessage(msg liveMessageMsg) tea.Cmd {
+	if msg.rtm != m.rtm {
+		return nil
+	}
+	cmds := []tea.Cmd{m.waitForLiveMessage()}
+
+	if m.selectedChannelID != "" && msg.message.ChannelID != m.selectedChannelID {
+		return tea.Batch(cmds...)
+	}
+
+	m.pendingMessages = append(m.pendingMessages, msg.message)
+	if !m.flushScheduled {
+		m.flushScheduled = true
+		interval := time.Duration(m.config.LiveRenderIntervalMs) * time.Millisecond
+		cmds = append(cmds, tea.Tick(interval, func(time.Time) tea.Msg {
+			return liveFlushMsg{}
+		}))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Add the buffered live messages to the view in one render, following them if the view was at the bottom
+func (m *Model) flushLiveMessages() {
+	m.flushScheduled = false
+	if len(m.pendingMessages) == 0 {
+		return
+	}
+
+	// Keep the same message selected as new ones arrive above or below it,
+	// unless the view is following the newest
+	selected, hadSelection := m.selectedMsg()
+	hadSelection = hadSelection && !m.following
+
+	// Skip messages a fetch has already loaded
+	loaded := make(map[string]bool, len(m.messages))
+	for _, msg := range m.messages {
+		loaded[msg.ChannelID+msg.Timestamp] = true
+	}
+	for _, msg := range m.pendingMessages {
+		if !loaded[msg.ChannelID+msg.Timestamp] {
+			m.messages = append(m.messages, msg)
+		}
+	}
+	m.pendingMessages = nil
+
+	if m.currentPage == pageMessages {
+		m.rememberSeen()
+	}
+
+	if hadSelection {
+		for i, msg := range m.visibleMessages() {
+			if msg.ChannelID == selected.ChannelID && msg.Timestamp == selected.Timestamp {
+				m.selectedMessage = i
+				break
+			}
+		}
+	}
+
+	// Follow the newest end of the view if the user was already there
+	following := m.atNewest()
+	m.refreshMessages()
+	if m.currentPage != pageMessages {
+		return
+	}
+	if m.following {
+		m.selectNewest()
+	} else if following {
+		m.gotoNewest()
+	}
+}
+
+// Whether the viewport is scrolled to its newest end
+func (m Model) atNewest() bool {
+	if m.newestFirst {
+		return m.viewport.AtTop()
+	}
+	return m.viewport.AtBottom()
+}
+
+// Scroll the viewport to its newest end
+func (m *Model) gotoNewest() {
+	if m.newestFirst {
+		m.viewport.GotoTop()
+	} else {
+		m.viewport.GotoBottom()
+	}
+}
+
+// Select the newest message and scroll to it
+func (m *Model) selectNewest() {
+	visible := m.visibleMessages()
+	if len(visible) == 0 {
+		return
+	}
+	if m.newestFirst {
+		m.selectedMessage = 0
+	} else {
+		m.selectedMessage = len(visible) - 1
+	}
+	m.refreshMessages()
+	m.gotoNewest()
+}
+
+// Jump to the oldest loaded message, leaving the newest end
+func (m *Model) jumpToOldest() {
+	visible := m.visibleMessages()
+	if len(visible) == 0 {
+		return
+	}
+	m.following = false
+	if m.newestFirst {
+		m.selectedMessage = len(visible) - 1
+		m.refreshMessages()
+		m.viewport.GotoBottom()
+	} else {
+		m.selectedMessage = 0
+		m.refreshMessages()
+		m.viewport.GotoTop()
+	}
+}
+
+// Jump to the newest message and keep following new ones as they arrive live,
+// until the selection moves or the view is scrolled away
+func (m *Model) jumpToLatest() {
+	m.selectNewest()
+	m.following = m.rtm != nil
+}
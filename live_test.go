@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestJumpToOldestAndLatest(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C1"
+
+	var messages []SlackMessage
+	for i := 0; i < 100; i++ {
+		ts := fmt.Sprintf("17000000%02d.000000", i)
+		messages = append(messages, SlackMessage{ChannelID: "C1", Content: fmt.Sprintf("message %d", i), Timestamp: ts, Time: parseSlackTimestamp(ts)})
+	}
+
+	// New messages arrive scrolled to the newest
+	updated, _ := m.handleMsg(messagesMsg{channelID: "C1", messages: messages})
+	m = updated.(Model)
+	if !m.viewport.AtBottom() {
+		t.Fatal("the viewport isn't at the newest message after loading")
+	}
+
+	updated, _ = m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m = updated.(Model)
+	if !m.viewport.AtTop() || m.viewport.AtBottom() {
+		t.Errorf("after g, at top %v, at bottom %v; want the top", m.viewport.AtTop(), m.viewport.AtBottom())
+	}
+
+	updated, _ = m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	m = updated.(Model)
+	if !m.viewport.AtBottom() {
+		t.Error("after G, the viewport isn't at the bottom")
+	}
+}
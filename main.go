@@ -1,14 +1,25 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,17 +27,31 @@ import (
 	"github.com/slack-go/slack"
 )
 
+// Colors of the styles, set from the theme
+var (
+	primaryColor   lipgloss.TerminalColor = lipgloss.Color(palettePrimary.dark)
+	secondaryColor lipgloss.TerminalColor = lipgloss.Color(paletteSecondary.dark)
+	accentColor    lipgloss.TerminalColor = lipgloss.Color(paletteAccent.dark)
+	errorColor     lipgloss.TerminalColor = lipgloss.Color(paletteError.dark)
+)
+
 // Constants for styling
 const (
-	// Color definitions
-	primaryColor   = lipgloss.Color("#6C8EBF")
-	secondaryColor = lipgloss.Color("#DAE8FC")
-	accentColor    = lipgloss.Color("#D5E8D4")
-	errorColor     = lipgloss.Color("#F8CECC")
-
 	// Layout constants
 	headerHeight = 3
 	footerHeight = 3
+
+	// How long transient notifications stay on screen
+	toastDuration = 3 * time.Second
+
+	// The startup banner stays a little longer, unless a key dismisses it
+	bannerDuration = 6 * time.Second
+
+	// Lines of the compose box; longer messages scroll inside it
+	composeHeight = 5
+
+	// Lines the mouse wheel scrolls the viewport unless scrollLines is set
+	defaultWheelLines = 3
 )
 
 // Global styles
@@ -55,6 +80,11 @@ var (
 	channelStyle = lipgloss.NewStyle().
 			Foreground(accentColor)
 
+	// Marks the selected channel wherever it appears; its color comes from the theme
+	selectedChannelStyle = lipgloss.NewStyle().
+				Bold(true).
+				Underline(true)
+
 	messageStyle = lipgloss.NewStyle().
 			PaddingLeft(2)
 
@@ -69,18 +99,90 @@ var (
 	statusDNDStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("9")).
 			Bold(true)
+
+	selectedMarkerStyle = lipgloss.NewStyle().
+				Foreground(primaryColor).
+				Bold(true)
+
+	menuStyle = lipgloss.NewStyle().
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(primaryColor).
+			Padding(0, 1)
+
+	toastStyle = lipgloss.NewStyle().
+			Foreground(accentColor).
+			Bold(true)
+
+	// Marks where a message matches the search
+	searchMatchStyle = lipgloss.NewStyle().
+				Reverse(true)
 )
 
+// Border styles selectable from the theme. "none" keeps the space a border would take so the layout doesn't shift.
+var borderStyles = map[string]lipgloss.Border{
+	"rounded": lipgloss.RoundedBorder(),
+	"normal":  lipgloss.NormalBorder(),
+	"thick":   lipgloss.ThickBorder(),
+	"double":  lipgloss.DoubleBorder(),
+	"none":    lipgloss.HiddenBorder(),
+}
+
+// Apply the configured theme to the global styles
+func applyTheme(theme Theme) {
+	primaryColor = themeColor(theme.Primary, palettePrimary, theme.Mode)
+	secondaryColor = themeColor(theme.Secondary, paletteSecondary, theme.Mode)
+	accentColor = themeColor(theme.Accent, paletteAccent, theme.Mode)
+	errorColor = themeColor(theme.Error, paletteError, theme.Mode)
+
+	appStyle = appStyle.BorderStyle(borderStyles[theme.AppBorder]).BorderForeground(primaryColor)
+	titleStyle = titleStyle.Foreground(primaryColor)
+	infoStyle = infoStyle.Foreground(secondaryColor)
+	errorStyle = errorStyle.Foreground(errorColor)
+	helpStyle = helpStyle.Foreground(secondaryColor)
+	channelStyle = channelStyle.Foreground(accentColor)
+	selectedMarkerStyle = selectedMarkerStyle.Foreground(primaryColor)
+	menuStyle = menuStyle.BorderForeground(primaryColor)
+	toastStyle = toastStyle.Foreground(accentColor)
+	selectedChannelStyle = selectedChannelStyle.Foreground(themeColor(theme.SelectedChannel, paletteSelectedChannel, theme.Mode))
+}
+
 // SlackMessage represents a message in Slack
 type SlackMessage struct {
-	User    string
-	Content string
-	Channel string
-	Time    time.Time
+	User      string
+	UserID    string
+	Content   string
+	Channel   string
+	ChannelID string
+	Timestamp string
+	Time      time.Time
+	Files     []slack.File
+	Edited    *slack.Edited
+	IsStarred bool
+	Reactions []slack.ItemReaction
+
+	// Timestamp of the thread's parent; the message's own for a parent, empty outside threads
+	ThreadTS string
+
+	// Replies under the message, if it starts a thread
+	ReplyCount int
+}
+
+// Whether the message is a reply inside a thread rather than a top-level message
+func (msg SlackMessage) isThreadReply() bool {
+	return msg.ThreadTS != "" && msg.ThreadTS != msg.Timestamp
+}
+
+// Timestamp of the message a reply should go under: the thread's parent, or the message itself
+func (msg SlackMessage) threadRoot() string {
+	if msg.isThreadReply() {
+		return msg.ThreadTS
+	}
+	return msg.Timestamp
 }
 
 // QuickAction represents a quick action like changing status or sending a preset message
 type QuickAction struct {
+	id          string
 	name        string
 	description string
 }
@@ -90,26 +192,153 @@ func (q QuickAction) Title() string       { return q.name }
 func (q QuickAction) Description() string { return q.description }
 func (q QuickAction) FilterValue() string { return q.name + " " + q.description }
 
+// ReactionOption represents an emoji that can be added as a reaction
+type ReactionOption struct {
+	name  string
+	emoji string
+}
+
+// Implement the list.Item interface
+func (r ReactionOption) Title() string {
+	if r.emoji == "" {
+		return fmt.Sprintf(":%s:", r.name)
+	}
+	return fmt.Sprintf("%s  :%s:", r.emoji, r.name)
+}
+func (r ReactionOption) Description() string { return "" }
+func (r ReactionOption) FilterValue() string { return r.name }
+
 // Model represents the application state
 type Model struct {
-	width             int
-	height            int
-	slackClient       *slack.Client
-	userID            string
-	userName          string
-	userStatus        string
-	messages          []SlackMessage
-	channels          []slack.Channel
-	spinner           spinner.Model
-	viewport          viewport.Model
-	quickActions      list.Model
-	presetMessages    list.Model
-	statusOptions     list.Model
-	textInput         textinput.Model
+	width           int
+	height          int
+	config          Config
+	configWarnings  []string
+	flags           flagOverrides
+	state           State
+	catchUpMarkers  map[string]string
+	slackClient     SlackAPI
+	rtm             *slack.RTM
+	pendingMessages []SlackMessage
+	flushScheduled  bool
+	liveState       string
+
+	// Keep the newest message selected and in view as live messages arrive
+	following   bool
+	lastInput   time.Time
+	autoAway    bool
+	idleSeq     int
+	newestFirst bool
+
+	// Order of the channel picker, and the timer keeping its presence fresh
+	channelSort        string
+	presenceRefreshSeq int
+	settingsList       list.Model
+	settingInput       textinput.Model
+	editingSetting     string
+	settingError       string
+	userID             string
+	userName           string
+	teamDomain         string
+	userStatus         string
+	messages           []SlackMessage
+	channels           []slack.Channel
+	directMessages     []slack.Channel
+	spinner            spinner.Model
+	viewport           viewport.Model
+	quickActions       list.Model
+	presetMessages     list.Model
+	statusOptions      list.Model
+	textInput          textinput.Model
+	searchInput        textinput.Model
+	searching          bool
+	searchQuery        string
+	messageActions     list.Model
+	reactionOptions    list.Model
+	codeBlocks         list.Model
+	fileOptions        list.Model
+	memberOptions      list.Model
+	fromUserID         string
+	fromUserName       string
+	fromUserChannelID  string
+	composeInput       textarea.Model
+	people             list.Model
+	users              []slack.User
+	userNames          *userCache
+	apiWarnings        *apiWarnings
+	usersRefreshSeq    int
+	userPages          slack.UserPagination
+	usersFetching      bool
+	usersComplete      bool
+	composeMode        string
+	composeMessage     SlackMessage
+	composeQuote       string
+	composeReturn      string
+	quickNote          bool
+
+	// Stay on the compose page after sending, with the input cleared for the next message
+	composeAgain bool
+
+	// Waiting for a y/n answer before quitting, with confirmQuit on
+	confirmingQuit bool
+
+	botToken           bool
+	composeAsPersona   bool
+	mentions           list.Model
+	channelList        list.Model
+	sparklines         map[string]sparkline
+	sparklineRequested map[string]bool
+	unreads            map[string]channelUnread
+	channelInfo        map[string]*slack.Channel
+	channelInfoID      string
+	unreadRequested    map[string]bool
+	roster             list.Model
+	rosterChannelID    string
+	rosterCursor       string
+	historyCursor      string
+	loadingOlder       bool
+	pins               []pinnedMessage
+	pinsChannelID      string
+	pinsExpanded       bool
+	rosterFetching     bool
+	rosterComplete     bool
+	rosterCount        int
+	presence           map[string]string
+	presenceRequested  map[string]bool
+	linkOptions        list.Model
+	download           *downloadJob
+	downloadList       list.Model
+	unreactOptions     list.Model
+	workspaceList      list.Model
+
+	// Status chosen on the status page, waiting on when it should clear
+	pendingStatus      StatusOption
+	statusClearOptions list.Model
+
+	// Name of the configured workspace connected to, empty for the default token
+	workspace         string
+	reactorOptions    list.Model
+	thread            []SlackMessage
+	threadLink        permalink
+	selectedMessage   int
+	showAllSubtypes   bool
+	showMuted         bool
+	detailKey         string
 	isLoading         bool
 	error             string
+	retry             tea.Cmd
+	toast             string
+	toastIsError      bool
+	toastID           int
+	bannerToastID     int
 	currentPage       string
 	selectedChannelID string
+	focusedChannelID  string
+	focusSeq          int
+	scheduleEnabled   bool
+	scheduleSeq       int
+	nextRule          StatusRule
+	nextRuleAt        time.Time
 }
 
 // Page constants
@@ -119,6 +348,75 @@ const (
 	pageQuickActions  = "quick_actions"
 	pagePresetMessage = "preset_message"
 	pageSetStatus     = "set_status"
+	pageMessageMenu   = "message_menu"
+	pageReactions     = "reactions"
+	pageCompose       = "compose"
+	pagePeople        = "people"
+	pageCodeBlocks    = "code_blocks"
+	pageFiles         = "files"
+	pageMembers       = "members"
+	pageMentions      = "mentions"
+	pageRoster        = "roster"
+	pageLinks         = "links"
+	pageThread        = "thread"
+	pageSettings      = "settings"
+	pageUnreact       = "unreact"
+	pageReactors      = "reactors"
+	pageWorkspaces    = "workspaces"
+	pageStatusClear   = "status_clear"
+	pageChannelList   = "channels"
+	pageChannelInfo   = "channel_info"
+	pageDownloads     = "downloads"
+)
+
+// Compose modes
+const (
+	composeReply   = "reply"
+	composeChannel = "channel"
+	composeEdit    = "edit"
+	composeNew     = "new"
+)
+
+// Where sending a new message leaves the user
+const (
+	afterSendMenu    = "menu"
+	afterSendChannel = "channel"
+	afterSendCompose = "compose"
+)
+
+var afterSendOptions = []string{afterSendMenu, afterSendChannel, afterSendCompose}
+
+// Quick action identifiers
+const (
+	quickViewMessages = "view_messages"
+	quickChannels     = "channels"
+	quickSetStatus    = "set_status"
+	quickSendPreset   = "send_preset"
+	quickCompose      = "compose"
+	quickPeople       = "people"
+	quickSettings     = "settings"
+	quickMentions     = "mentions"
+	quickWorkspaces   = "workspaces"
+	quickQuit         = "quit"
+)
+
+// Message action identifiers
+const (
+	actionReact   = "react"
+	actionReply   = "reply"
+	actionQuote   = "quote"
+	actionQuoteCh = "quote_channel"
+	actionCopy    = "copy"
+	actionCode    = "code"
+	actionLink    = "link"
+	actionPin     = "pin"
+	actionEdit    = "edit"
+	actionDelete  = "delete"
+	actionBrowser = "browser"
+	actionFile    = "file"
+	actionJump    = "jump"
+	actionPager   = "pager"
+	actionThread  = "thread"
 )
 
 // Status constants
@@ -126,310 +424,587 @@ const (
 	statusActive = "active"
 	statusAway   = "away"
 	statusDND    = "dnd"
+
+	// No custom status, with presence left to Slack's automatic tracking
+	statusCleared = "cleared"
 )
 
 // Initialize the application model
-func initialModel() Model {
+func initialModel(cfg Config, state State) Model {
+	// The locale goes first so the config warnings come out in it
+	setLocale(cfg.Locale)
+	warnings := cfg.validate()
+	applyTheme(cfg.Theme)
+
 	// Initialize spinner
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(primaryColor)
 
 	// Initialize quick actions
+	quickActions := quickActionItems(cfg)
+
+	// Initialize preset messages
+	presetMessages := presetItems(cfg)
+
+	// Initialize status options
+	statusOptions := statusItems(cfg)
+
+	// Initialize reaction options
+	reactionOptions := reactionItems(cfg)
+
+	// Initialize list delegates
+	actionDelegate, menuDelegate := listDelegates()
+
+	// Create the lists
+	quickActionList := list.New(quickActions, actionDelegate, 0, 0)
+	quickActionList.SetShowHelp(false)
+
+	presetMessageList := list.New(presetMessages, actionDelegate, 0, 0)
+	presetMessageList.SetShowHelp(false)
+
+	statusList := list.New(statusOptions, actionDelegate, 0, 0)
+	statusList.SetShowHelp(false)
+
+	workspaceList := list.New(nil, actionDelegate, 0, 0)
+	workspaceList.SetShowHelp(false)
+	workspaceList.SetFilteringEnabled(false)
+
+	peopleList := list.New(nil, actionDelegate, 0, 0)
+	peopleList.SetShowHelp(false)
+
+	mentionList := list.New(nil, actionDelegate, 0, 0)
+	mentionList.SetShowHelp(false)
+
+	channelList := list.New(nil, actionDelegate, 0, 0)
+	channelList.SetShowHelp(false)
+	channelList.SetFilteringEnabled(false)
+
+	rosterList := list.New(nil, actionDelegate, 0, 0)
+	rosterList.SetShowHelp(false)
+
+	downloadList := list.New(nil, actionDelegate, 0, 0)
+	downloadList.SetShowHelp(false)
+
+	settingsList := list.New(nil, actionDelegate, 0, 0)
+	settingsList.SetShowHelp(false)
+
+	// Menus shown over the message view
+	messageActionList := newMenuList(nil, menuDelegate)
+	reactionList := newMenuList(reactionOptions, menuDelegate)
+	codeBlockList := newMenuList(nil, menuDelegate)
+	fileList := newMenuList(nil, menuDelegate)
+	memberList := newMenuList(nil, menuDelegate)
+	memberList.SetFilteringEnabled(true)
+	linkList := newMenuList(nil, menuDelegate)
+	unreactList := newMenuList(nil, menuDelegate)
+	reactorList := newMenuList(nil, menuDelegate)
+	statusClearList := newMenuList(statusClearItems(), menuDelegate)
+
+	// Initialize text input
+	ti := textinput.New()
+	ti.Focus()
+	ti.CharLimit = 156
+	ti.Width = 20
+
+	// Initialize the compose input used for replies and edits
+	ci := textarea.New()
+	ci.CharLimit = 4000
+	ci.ShowLineNumbers = false
+	ci.SetWidth(40)
+	ci.SetHeight(composeHeight)
+	ci.KeyMap.InsertNewline = key.NewBinding(key.WithKeys(cfg.Keys.NewLine))
+
+	// Initialize the input for editing a setting inline
+	si := textinput.New()
+	si.CharLimit = 100
+	si.Width = 30
+
+	// Initialize the search of the loaded messages
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.CharLimit = 100
+	search.Width = 40
+
+	// Create the viewport
+	vp := viewport.New(0, 0)
+	vp.MouseWheelDelta = wheelLines(cfg)
+	vp.Style = lipgloss.NewStyle().
+		BorderStyle(borderStyles[cfg.Theme.ViewportBorder]).
+		BorderForeground(primaryColor)
+
+	// Initialize the model
+	m := Model{
+		config:             cfg,
+		configWarnings:     warnings,
+		state:              state,
+		currentPage:        pageMain,
+		spinner:            s,
+		isLoading:          true, // Connecting until initSlackClient reports back
+		quickActions:       quickActionList,
+		presetMessages:     presetMessageList,
+		statusOptions:      statusList,
+		textInput:          ti,
+		messageActions:     messageActionList,
+		reactionOptions:    reactionList,
+		codeBlocks:         codeBlockList,
+		fileOptions:        fileList,
+		memberOptions:      memberList,
+		linkOptions:        linkList,
+		unreactOptions:     unreactList,
+		workspaceList:      workspaceList,
+		statusClearOptions: statusClearList,
+		workspace:          cfg.startupWorkspace(),
+		reactorOptions:     reactorList,
+		composeInput:       ci,
+		people:             peopleList,
+		mentions:           mentionList,
+		channelList:        channelList,
+		sparklines:         make(map[string]sparkline),
+		sparklineRequested: make(map[string]bool),
+		unreads:            make(map[string]channelUnread),
+		channelInfo:        make(map[string]*slack.Channel),
+		unreadRequested:    make(map[string]bool),
+		roster:             rosterList,
+		downloadList:       downloadList,
+		settingsList:       settingsList,
+		settingInput:       si,
+		searchInput:        search,
+		userNames:          newUserCache(),
+		apiWarnings:        newAPIWarnings(),
+		presence:           make(map[string]string),
+		presenceRequested:  make(map[string]bool),
+		viewport:           vp,
+		userStatus:         statusActive,
+		scheduleEnabled:    true,
+		lastInput:          time.Now(),
+		newestFirst:        cfg.NewestFirst,
+		channelSort:        cfg.ChannelSort,
+	}
+	m.applyLocale()
+	m.planNextRule(time.Now())
+
+	return m
+}
+
+// The main menu's quick actions in the config's language, numbered for picking by digit
+func quickActionItems(cfg Config) []list.Item {
 	quickActions := []list.Item{
 		QuickAction{
-			name:        "View Messages",
-			description: "View recent messages from Slack",
-		},
-		QuickAction{
-			name:        "Set Status",
-			description: "Change your Slack status",
-		},
-		QuickAction{
-			name:        "Send Preset Message",
-			description: "Send a pre-configured message",
+			id:          quickViewMessages,
+			name:        tr("menu.view_messages"),
+			description: tr("menu.view_messages.d"),
 		},
 		QuickAction{
-			name:        "Quit",
-			description: "Exit the application",
+			id:          quickSetStatus,
+			name:        tr("menu.set_status"),
+			description: tr("menu.set_status.d"),
 		},
-	}
-
-	// Initialize preset messages
-	presetMessages := []list.Item{
 		QuickAction{
-			name:        "Be Right Back",
-			description: "I'll be right back, give me a few minutes.",
+			id:          quickChannels,
+			name:        tr("menu.channels"),
+			description: tr("menu.channels.d"),
 		},
 		QuickAction{
-			name:        "In a Meeting",
-			description: "I'm currently in a meeting, will respond later.",
+			id:          quickSendPreset,
+			name:        tr("menu.send_preset"),
+			description: tr("menu.send_preset.d"),
 		},
 		QuickAction{
-			name:        "Working on Issue",
-			description: "I'm working on the issue, will update you soon.",
+			id:          quickCompose,
+			name:        tr("menu.compose"),
+			description: tr("menu.compose.d"),
 		},
 		QuickAction{
-			name:        "Lunch Break",
-			description: "I'm on lunch break, back in an hour.",
+			id:          quickPeople,
+			name:        tr("menu.people"),
+			description: tr("menu.people.d"),
 		},
-	}
-
-	// Initialize status options
-	statusOptions := []list.Item{
 		QuickAction{
-			name:        "Active",
-			description: "Set your status to active",
+			id:          quickMentions,
+			name:        tr("menu.mentions"),
+			description: tr("menu.mentions.d"),
 		},
 		QuickAction{
-			name:        "Away",
-			description: "Set your status to away",
+			id:          quickSettings,
+			name:        tr("menu.settings"),
+			description: tr("menu.settings.d"),
 		},
 		QuickAction{
-			name:        "Do Not Disturb",
-			description: "Set your status to do not disturb",
+			id:          quickQuit,
+			name:        tr("menu.quit"),
+			description: tr("menu.quit.d"),
 		},
 	}
 
-	// Initialize list delegates
-	actionDelegate := list.NewDefaultDelegate()
-	actionDelegate.Styles.SelectedTitle = actionDelegate.Styles.SelectedTitle.
+	// Switching workspaces only makes sense with some configured
+	if len(cfg.Workspaces) > 0 {
+		quickActions = slices.Insert(quickActions, len(quickActions)-1, list.Item(QuickAction{
+			id:          quickWorkspaces,
+			name:        tr("menu.workspaces"),
+			description: tr("menu.workspaces.d"),
+		}))
+	}
+
+	// Number the first nine, so a digit picks them straight away
+	for i := 0; i < len(quickActions) && i < 9; i++ {
+		action := quickActions[i].(QuickAction)
+		action.name = fmt.Sprintf("%d. %s", i+1, action.name)
+		quickActions[i] = action
+	}
+	return quickActions
+}
+
+// The delegates lists are drawn with, colored from the current theme: the full one
+// with descriptions, and the compact single-line one of menus shown over the message view
+func listDelegates() (action, menu list.DefaultDelegate) {
+	action = list.NewDefaultDelegate()
+	action.Styles.SelectedTitle = action.Styles.SelectedTitle.
 		Foreground(lipgloss.Color("0")).
 		Background(primaryColor).
 		Bold(true)
-	actionDelegate.Styles.SelectedDesc = actionDelegate.Styles.SelectedDesc.
+	action.Styles.SelectedDesc = action.Styles.SelectedDesc.
 		Foreground(lipgloss.Color("0")).
 		Background(primaryColor)
 
-	// Create the lists
-	quickActionList := list.New(quickActions, actionDelegate, 0, 0)
-	quickActionList.Title = "Quick Actions"
-	quickActionList.SetShowHelp(false)
+	menu = list.NewDefaultDelegate()
+	menu.ShowDescription = false
+	menu.SetSpacing(0)
+	menu.Styles.SelectedTitle = action.Styles.SelectedTitle
+	return action, menu
+}
 
-	presetMessageList := list.New(presetMessages, actionDelegate, 0, 0)
-	presetMessageList.Title = "Preset Messages"
-	presetMessageList.SetShowHelp(false)
+// Name the lists and inputs in the current language
+func (m *Model) applyLocale() {
+	m.quickActions.Title = tr("menu.quick_actions")
+	m.presetMessages.Title = tr("presets.title")
+	m.statusOptions.Title = tr("status.title")
+	m.workspaceList.Title = tr("workspaces.title")
+	m.people.Title = tr("people.title")
+	m.mentions.Title = tr("menu.mentions")
+	m.channelList.Title = tr("channels.title")
+	m.settingsList.Title = tr("settings.title")
+	m.messageActions.Title = tr("actions.title")
+	m.reactionOptions.Title = tr("reactions.title")
+	m.codeBlocks.Title = tr("codeblocks.title")
+	m.fileOptions.Title = tr("files.title")
+	m.memberOptions.Title = tr("members.title")
+	m.linkOptions.Title = tr("links.title")
+	m.unreactOptions.Title = tr("unreact.title")
+	m.reactorOptions.Title = tr("reactors.title")
+	m.statusClearOptions.Title = tr("statusclear.title")
+	m.statusClearOptions.SetItems(statusClearItems())
+
+	m.textInput.Placeholder = tr("filter.placeholder")
+	m.composeInput.Placeholder = tr("compose.placeholder")
+	m.searchInput.Placeholder = tr("search.placeholder")
+}
 
-	statusList := list.New(statusOptions, actionDelegate, 0, 0)
-	statusList.Title = "Set Status"
-	statusList.SetShowHelp(false)
+// Draw the lists with delegates colored from the current theme
+func (m *Model) applyListDelegates() {
+	action, menu := listDelegates()
+	for _, l := range []*list.Model{
+		&m.quickActions, &m.presetMessages, &m.statusOptions, &m.workspaceList, &m.people,
+		&m.mentions, &m.channelList, &m.roster, &m.downloadList, &m.settingsList,
+	} {
+		l.SetDelegate(action)
+	}
+	for _, l := range []*list.Model{
+		&m.messageActions, &m.reactionOptions, &m.codeBlocks, &m.fileOptions, &m.memberOptions,
+		&m.linkOptions, &m.unreactOptions, &m.reactorOptions, &m.statusClearOptions,
+	} {
+		l.SetDelegate(menu)
+	}
+}
 
-	// Initialize text input
-	ti := textinput.New()
-	ti.Placeholder = "Type a channel name to filter..."
-	ti.Focus()
-	ti.CharLimit = 156
-	ti.Width = 20
+// Create a small menu list without the status bar, filtering, or help
+func newMenuList(items []list.Item, delegate list.ItemDelegate) list.Model {
+	l := list.New(items, delegate, 30, len(items)+4)
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	return l
+}
 
-	// Create the viewport
-	vp := viewport.New(0, 0)
-	vp.Style = lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(primaryColor)
+// Initialize the Slack client for the workspace the app starts in
+func (m *Model) initSlackClient() tea.Msg {
+	return m.connectWorkspace(m.workspace)
+}
 
-	// Initialize the model
-	return Model{
-		currentPage:    pageMain,
-		spinner:        s,
-		isLoading:      false,
-		quickActions:   quickActionList,
-		presetMessages: presetMessageList,
-		statusOptions:  statusList,
-		textInput:      ti,
-		viewport:       vp,
-		userStatus:     statusActive,
-	}
+// Connect to a workspace, the default token's for an empty name
+func (m *Model) connectWorkspace(name string) tea.Msg {
+	retry := func() tea.Msg { return m.connectWorkspace(name) }
+	return m.awaitTimeout(tr("error.connect_timeout"), func() tea.Msg { return m.dialWorkspace(name, retry) }, retry)
 }
 
-// Initialize the Slack client
-func (m *Model) initSlackClient() tea.Msg {
-	token := os.Getenv("SLACK_TOKEN")
+// Sign in to a workspace and read its channels, then start the live connection
+func (m *Model) dialWorkspace(name string, retry tea.Cmd) tea.Msg {
+	token, err := m.config.workspaceToken(name)
+	if err != nil {
+		return authErrMsg{appError{text: tr("error.token_read"), err: err}}
+	}
+	if token == "" && name != "" {
+		return authErrMsg{appError{text: fmt.Sprintf(tr("error.no_workspace_token"), name)}}
+	}
 	if token == "" {
-		return errMsg("SLACK_TOKEN environment variable not set")
+		return authErrMsg{appError{text: tr("error.no_token")}}
 	}
 
-	client := slack.New(token)
-	rtm := client.NewRTM()
-	go rtm.ManageConnection()
+	// Space out requests per rate limit tier instead of waiting to be throttled
+	var httpClient httpDoer = &http.Client{}
+	if m.config.RateLimit {
+		httpClient = newRateLimitedClient(&http.Client{})
+	}
 
-	// Get user info
-	info := rtm.GetInfo()
-	if info == nil {
-		return errMsg("Failed to connect to Slack. Check your token.")
+	client := slack.New(token, slack.OptionHTTPClient(&warningClient{next: httpClient, warnings: m.apiWarnings}))
+
+	// Check the token before connecting, so a rejected one reports Slack's reason
+	// instead of leaving the live connection retrying in the background
+	auth, err := client.AuthTest()
+	if err != nil {
+		return classifyError(tr("error.sign_in"), err, retry)
 	}
 
-	// Get channels
-	channels, _, err := client.GetConversations(&slack.GetConversationsParameters{
-		ExcludeArchived: true,
-		Types:           []string{"public_channel", "private_channel"},
-	})
+	// Get channels, starting with the pages read if a later one fails
+	channels, err := fetchConversations(client, "public_channel", "private_channel")
+	if err != nil && len(channels) == 0 {
+		return classifyError(tr("error.channels"), err, retry)
+	}
 	if err != nil {
-		return errMsg(fmt.Sprintf("Error getting channels: %v", err))
+		log.Printf("Error getting channels after the first %d: %v", len(channels), err)
 	}
 
+	// Direct and group direct messages only add to the picker, so go without the ones that can't be read
+	var directMessages []slack.Channel
+	ims, _ := fetchConversations(client, "im", "mpim")
+	for _, im := range ims {
+		if !im.IsUserDeleted {
+			directMessages = append(directMessages, im)
+		}
+	}
+
+	rtm := client.NewRTM()
+	go rtm.ManageConnection()
+
 	return initMsg{
-		client:   client,
-		userID:   info.User.ID,
-		userName: info.User.Name,
-		channels: channels,
+		workspace:  name,
+		client:     client,
+		rtm:        rtm,
+		userID:     auth.UserID,
+		userName:   auth.User,
+		teamDomain: teamDomainFromURL(auth.URL),
+		botToken:   strings.HasPrefix(token, "xoxb-"),
+		channels:   channels,
+
+		directMessages: directMessages,
 	}
 }
 
 // Get recent messages from Slack
 func (m *Model) fetchMessages() tea.Msg {
+	return m.awaitTimeout(tr("error.fetch_timeout"), m.loadMessages, m.fetchMessages)
+}
+
+// Read the selected channel's latest page of history, or the combined view's channels
+func (m *Model) loadMessages() tea.Msg {
 	if m.slackClient == nil {
-		return errMsg("Slack client not initialized")
+		return genericErr(tr("error.client_missing"))
 	}
 
-	var messages []SlackMessage
-
-	// If no channel is selected, get messages from all channels
+	// With no channel selected, show the latest few messages from the first channels.
+	// Only a single channel's history can be paged back through.
 	if m.selectedChannelID == "" {
-		// Limit to 5 most recent channels to avoid rate limits
-		channelLimit := 5
-		if len(m.channels) < channelLimit {
-			channelLimit = len(m.channels)
-		}
-
-		for i := 0; i < channelLimit; i++ {
-			channel := m.channels[i]
-			history, err := m.slackClient.GetConversationHistory(&slack.GetConversationHistoryParameters{
-				ChannelID: channel.ID,
-				Limit:     3, // Get last 3 messages per channel
-			})
-			if err != nil {
-				return errMsg(fmt.Sprintf("Error fetching messages: %v", err))
-			}
-
-			for j := len(history.Messages) - 1; j >= 0; j-- {
-				msg := history.Messages[j]
-				userName := "Unknown User"
-
-				// Get username if it's not a bot message
-				if msg.User != "" {
-					user, err := m.slackClient.GetUserInfo(msg.User)
-					if err == nil {
-						userName = user.Name
-					}
-				}
-
-				messages = append(messages, SlackMessage{
-					User:    userName,
-					Content: msg.Text,
-					Channel: channel.Name,
-					Time:    parseSlackTimestamp(msg.Timestamp),
-				})
-			}
-		}
-	} else {
-		// Get messages for a specific channel
-		history, err := m.slackClient.GetConversationHistory(&slack.GetConversationHistoryParameters{
-			ChannelID: m.selectedChannelID,
-			Limit:     10, // Get last 10 messages from selected channel
-		})
+		messages, failed, err := m.fetchAggregateMessages(m.aggregateChannels(), m.config.AllChannelsLimit)
 		if err != nil {
-			return errMsg(fmt.Sprintf("Error fetching messages: %v", err))
+			return classifyError(tr("error.fetch_messages"), err, m.fetchMessages)
 		}
+		return messagesMsg{messages: messages, failed: failed}
+	}
 
-		var channelName string
-		for _, ch := range m.channels {
-			if ch.ID == m.selectedChannelID {
-				channelName = ch.Name
-				break
-			}
-		}
+	messages, cursor, err := m.fetchChannelMessages(m.selectedChannelID, m.config.SingleChannelLimit, "")
+	if err != nil {
+		return classifyError(tr("error.fetch_messages"), err, m.fetchMessages)
+	}
+	return messagesMsg{channelID: m.selectedChannelID, messages: messages, cursor: cursor}
+}
+
+// Fetch a page of a channel's history, oldest first, along with the cursor for the
+// older page before it. Only the last historyHours are read when that's set.
+func (m *Model) fetchChannelMessages(channelID string, limit int, cursor string) ([]SlackMessage, string, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Cursor:    cursor,
+		Limit:     limit,
+	}
+	if m.config.HistoryHours > 0 {
+		params.Oldest = fmt.Sprintf("%d.000000", time.Now().Add(-time.Duration(m.config.HistoryHours)*time.Hour).Unix())
+	}
 
-		for j := len(history.Messages) - 1; j >= 0; j-- {
-			msg := history.Messages[j]
-			userName := "Unknown User"
+	history, err := m.slackClient.GetConversationHistory(params)
+	if err != nil {
+		return nil, "", err
+	}
 
-			// Get username if it's not a bot message
-			if msg.User != "" {
-				user, err := m.slackClient.GetUserInfo(msg.User)
-				if err == nil {
-					userName = user.Name
-				}
-			}
+	channelName := m.channelName(channelID)
 
-			messages = append(messages, SlackMessage{
-				User:    userName,
-				Content: msg.Text,
-				Channel: channelName,
-				Time:    parseSlackTimestamp(msg.Timestamp),
-			})
+	var messages []SlackMessage
+	for j := len(history.Messages) - 1; j >= 0; j-- {
+		msg := history.Messages[j]
+		if m.isHiddenSubtype(msg.SubType) {
+			continue
 		}
+		m.cacheMentionedUsers(msg.Text)
+		messages = append(messages, SlackMessage{
+			User:      m.messageAuthor(msg.Msg),
+			UserID:    msg.User,
+			Content:   msg.Text,
+			Channel:   channelName,
+			ChannelID: channelID,
+			Timestamp: msg.Timestamp,
+			Time:      parseSlackTimestamp(msg.Timestamp),
+			Files:     msg.Files,
+			Edited:    msg.Edited,
+			IsStarred: msg.IsStarred,
+			Reactions: msg.Reactions,
+			ThreadTS:  msg.ThreadTimestamp,
+
+			ReplyCount: msg.ReplyCount,
+		})
 	}
 
-	return messagesMsg{messages: messages}
+	return messages, history.ResponseMetaData.NextCursor, nil
+}
+
+// Whether messages of this subtype are filtered out of the views
+func (m *Model) isHiddenSubtype(subtype string) bool {
+	if subtype == "" || m.showAllSubtypes {
+		return false
+	}
+	for _, hidden := range m.config.HideSubtypes {
+		if hidden == subtype {
+			return true
+		}
+	}
+	return false
 }
 
-// Parse a Slack timestamp into a time.Time
+// Parse a Slack timestamp like "1609459200.000400" (Unix seconds and
+// microseconds) into a time.Time. Returns the zero time, rather than the
+// epoch, if it can't be parsed, so callers can tell with IsZero.
 func parseSlackTimestamp(timestamp string) time.Time {
-	parts := strings.Split(timestamp, ".")
-	if len(parts) != 2 {
+	secs, micros, ok := strings.Cut(timestamp, ".")
+	if !ok {
 		return time.Time{}
 	}
 
-	sec, err := fmt.Sscanf(parts[0], "%d", new(int64))
+	sec, err := strconv.ParseInt(secs, 10, 64)
 	if err != nil {
 		return time.Time{}
 	}
+	usec, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil || usec < 0 || usec >= 1e6 {
+		return time.Time{}
+	}
 
-	return time.Unix(int64(sec), 0)
+	return time.Unix(sec, usec*int64(time.Microsecond))
 }
 
-// Update the user's status
-func (m *Model) setStatus(status string) tea.Msg {
+// Update the user's status, clearing it at the expiration Unix time unless that's 0
+func (m *Model) setStatus(status StatusOption, expiration int64) tea.Msg {
 	if m.slackClient == nil {
-		return errMsg("Slack client not initialized")
+		return genericErr(tr("error.client_missing"))
 	}
 
-	var emojiText, statusText string
+	retry := func() tea.Msg { return m.setStatus(status, expiration) }
 
-	switch status {
-	case statusActive:
-		emojiText = ":white_check_mark:"
-		statusText = "Active"
-	case statusAway:
-		emojiText = ":away:"
-		statusText = "Away"
-	case statusDND:
-		emojiText = ":no_entry:"
-		statusText = "Do Not Disturb"
-	default:
-		return errMsg("Invalid status")
+	if presence, ok := slackPresence(status.Presence); ok {
+		if err := m.slackClient.SetUserPresence(presence); err != nil {
+			return classifyError(tr("error.set_presence"), err, retry)
+		}
 	}
 
-	err := m.slackClient.SetUserPresence(status)
+	err := m.slackClient.SetUserCustomStatus(status.StatusText, status.Emoji, expiration)
 	if err != nil {
-		return errMsg(fmt.Sprintf("Error setting presence: %v", err))
+		return classifyError(tr("error.set_status"), err, retry)
 	}
 
-	err = m.slackClient.SetUserCustomStatus(statusText, emojiText, 0)
-	if err != nil {
-		return errMsg(fmt.Sprintf("Error setting status: %v", err))
+	return statusUpdatedMsg{status: status.Presence, label: status.label(), expiration: expiration}
+}
+
+// The name a status is listed and confirmed by
+func (s StatusOption) label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return statusLabel(s.Presence)
+}
+
+// The Set Status choices, each described by the custom status it sets
+func statusItems(cfg Config) []list.Item {
+	items := make([]list.Item, len(cfg.Statuses))
+	for i, status := range cfg.Statuses {
+		description := strings.TrimSpace(status.Emoji + " " + status.StatusText)
+		if status.Name == "" {
+			description = tr("status." + status.Presence + ".d")
+		}
+		items[i] = QuickAction{id: strconv.Itoa(i), name: status.label(), description: description}
+	}
+	return append(items, QuickAction{id: statusClearID, name: tr("status.clear"), description: tr("status.clear.d")})
+}
+
+// The configured status the cycle-status key sets for a presence: the first one with it
+func (m Model) statusForPresence(presence string) StatusOption {
+	for _, status := range m.config.Statuses {
+		if status.Presence == presence {
+			return status
+		}
+	}
+	for _, status := range defaultConfig().Statuses {
+		if status.Presence == presence {
+			return status
+		}
+	}
+	return StatusOption{Presence: presence}
+}
+
+// Preset messages offered when the config has none of its own
+var builtinPresets = []Preset{
+	{Name: "Be Right Back", Description: "I'll be right back, give me a few minutes."},
+	{Name: "In a Meeting", Description: "I'm currently in a meeting, will respond later."},
+	{Name: "Working on Issue", Description: "I'm working on the issue, will update you soon."},
+	{Name: "Lunch Break", Description: "I'm on lunch break, back in an hour."},
+}
+
+// The preset messages to offer: the configured ones, after the built-in ones if
+// asked to keep them, or the built-in ones alone when none are configured
+func presetItems(cfg Config) []list.Item {
+	presets := cfg.Presets
+	if len(presets) == 0 {
+		presets = builtinPresets
+	} else if cfg.KeepBuiltinPresets {
+		presets = append(slices.Clone(builtinPresets), presets...)
 	}
 
-	return statusUpdatedMsg{status: status}
+	items := make([]list.Item, len(presets))
+	for i, preset := range presets {
+		items[i] = QuickAction{name: preset.Name, description: preset.Description}
+	}
+	return items
 }
 
 // Send a preset message
 func (m *Model) sendPresetMessage(message string) tea.Msg {
 	if m.slackClient == nil {
-		return errMsg("Slack client not initialized")
+		return genericErr(tr("error.client_missing"))
 	}
 
 	if m.selectedChannelID == "" {
-		return errMsg("No channel selected")
+		return genericErr(tr("error.no_channel"))
 	}
 
-	_, timestamp, err := m.slackClient.PostMessage(
-		m.selectedChannelID,
-		slack.MsgOptionText(message, false),
-		slack.MsgOptionAsUser(true),
-	)
+	timestamp, err := m.sendMessage(m.selectedChannelID, message, slack.MsgOptionAsUser(true))
 	if err != nil {
-		return errMsg(fmt.Sprintf("Error sending message: %v", err))
+		return classifyError(tr("error.send_message"), err, nil)
 	}
 
 	return messageSentMsg{
@@ -439,158 +1014,2067 @@ func (m *Model) sendPresetMessage(message string) tea.Msg {
 	}
 }
 
-// Custom messages for our application
-type initMsg struct {
-	client   *slack.Client
-	userID   string
-	userName string
-	channels []slack.Channel
+// Post text to a channel, returning the new message's timestamp
+func (m *Model) sendMessage(channelID, text string, options ...slack.MsgOption) (string, error) {
+	_, timestamp, err := m.slackClient.PostMessage(channelID, append([]slack.MsgOption{slack.MsgOptionText(text, false)}, options...)...)
+	return timestamp, err
 }
 
-type errMsg struct {
-	err string
-}
+// Build the action menu for a message, offering only what the user may do with it
+func (m *Model) openMessageMenu(msg SlackMessage) {
+	items := []list.Item{
+		QuickAction{id: actionReact, name: tr("actions.react"), description: tr("actions.react.d")},
+		QuickAction{id: actionReply, name: tr("actions.reply"), description: tr("actions.reply.d")},
+		QuickAction{id: actionQuote, name: tr("actions.quote"), description: tr("actions.quote.d")},
+		QuickAction{id: actionQuoteCh, name: tr("actions.quote_channel"), description: tr("actions.quote_channel.d")},
+		QuickAction{id: actionCopy, name: tr("actions.copy"), description: tr("actions.copy.d")},
+	}
 
-func (e errMsg) Error() string { return e.err }
+	// Offer to copy just the code when the message has code blocks
+	if len(extractCodeBlocks(msg.Content)) > 0 {
+		items = append(items, QuickAction{id: actionCode, name: tr("actions.code"), description: tr("actions.code.d")})
+	}
 
-type messagesMsg struct {
-	messages []SlackMessage
-}
+	items = append(items,
+		QuickAction{id: actionLink, name: tr("actions.link"), description: tr("actions.link.d")},
+		QuickAction{id: actionPin, name: tr("actions.pin"), description: tr("actions.pin.d")},
+	)
 
-type statusUpdatedMsg struct {
-	status string
-}
+	// Only the author can edit or delete a message
+	if msg.UserID != "" && msg.UserID == m.userID {
+		items = append(items,
+			QuickAction{id: actionEdit, name: tr("actions.edit"), description: tr("actions.edit.d")},
+			QuickAction{id: actionDelete, name: tr("actions.delete"), description: tr("actions.delete.d")},
+		)
+	}
 
-type messageSentMsg struct {
-	channelID string
-	timestamp string
-	text      string
-}
+	// Only files the user can reach can be opened
+	if len(openableFiles(msg.Files)) > 0 {
+		items = append(items, QuickAction{id: actionFile, name: tr("actions.file"), description: tr("actions.file.d")})
+	}
 
-// Initialize the application
-func (m Model) Init() tea.Cmd {
-	return tea.Batch(
-		spinner.Tick,
-		func() tea.Msg {
-			m.isLoading = true
-			return nil
-		},
-		m.initSlackClient,
+	// Read the whole thread a message starts or belongs to
+	if msg.ThreadTS != "" {
+		items = append(items, QuickAction{id: actionThread, name: tr("actions.thread"), description: tr("actions.thread.d")})
+	}
+
+	// Jump to messages it links to without leaving the app
+	if len(findPermalinks(msg.Content)) > 0 {
+		items = append(items, QuickAction{id: actionJump, name: tr("actions.jump"), description: tr("actions.jump.d")})
+	}
+
+	items = append(items,
+		QuickAction{id: actionPager, name: tr("actions.pager"), description: tr("actions.pager.d")},
+		QuickAction{id: actionBrowser, name: tr("actions.browser"), description: tr("actions.browser.d")},
 	)
+
+	m.messageActions.SetItems(items)
+	m.messageActions.SetHeight(len(items) + 4)
+	m.messageActions.Select(0)
+	m.currentPage = pageMessageMenu
 }
 
-// Update the application state based on messages
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
+// Dispatch the chosen message action
+func (m *Model) runMessageAction(action string, msg SlackMessage) tea.Cmd {
+	switch action {
+	case actionReact:
+		m.reactionOptions.Select(0)
+		m.currentPage = pageReactions
+	case actionReply:
+		m.startCompose(composeReply, msg)
+	case actionQuote:
+		m.startCompose(composeReply, msg)
+		m.composeQuote = msg.Content
+	case actionQuoteCh:
+		m.startCompose(composeChannel, msg)
+		m.composeQuote = msg.Content
+	case actionEdit:
+		m.startCompose(composeEdit, msg)
+	case actionCopy:
+		m.currentPage = pageMessages
+		return func() tea.Msg {
+			return copyToClipboard(msg.Content, tr("toast.copied"))
+		}
+	case actionCode:
+		blocks := extractCodeBlocks(msg.Content)
+		if len(blocks) == 1 {
+			m.currentPage = pageMessages
+			return func() tea.Msg {
+				return copyToClipboard(blocks[0], tr("toast.code_copied"))
+			}
+		}
 
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			if m.currentPage == pageMain {
-				return m, tea.Quit
+		// Let the user pick which block to copy
+		items := make([]list.Item, len(blocks))
+		for i, block := range blocks {
+			items[i] = QuickAction{
+				id:          strconv.Itoa(i),
+				name:        fmt.Sprintf("%d: %s", i+1, codeBlockPreview(block)),
+				description: block,
+			}
+		}
+		m.codeBlocks.SetItems(items)
+		m.codeBlocks.SetHeight(len(items) + 4)
+		m.codeBlocks.Select(0)
+		m.currentPage = pageCodeBlocks
+	case actionFile:
+		files := openableFiles(msg.Files)
+		if len(files) == 1 {
+			m.currentPage = pageMessages
+			return openFileCmd(files[0])
+		}
+
+		// Let the user pick which file to open
+		items := make([]list.Item, len(files))
+		for i, f := range files {
+			items[i] = QuickAction{id: f.ID, name: fileName(f), description: fileURL(f)}
+		}
+		m.fileOptions.SetItems(items)
+		m.fileOptions.SetHeight(len(items) + 4)
+		m.fileOptions.Select(0)
+		m.currentPage = pageFiles
+	case actionJump:
+		links := findPermalinks(msg.Content)
+		if len(links) == 1 {
+			m.currentPage = pageMessages
+			return m.followPermalink(links[0])
+		}
+		m.openLinkPicker(links)
+	case actionThread:
+		return m.openThread(msg)
+	case actionPager:
+		m.currentPage = pageMessages
+		return openInPager(msg)
+	case actionLink:
+		m.currentPage = pageMessages
+		return func() tea.Msg {
+			link, err := m.getPermalink(msg)
+			if err != nil {
+				return actionResultMsg{text: tr("error.get_link"), err: err}
+			}
+			return copyToClipboard(link, tr("toast.link_copied"))
+		}
+	case actionPin:
+		m.currentPage = pageMessages
+		return func() tea.Msg {
+			return m.pinMessage(msg)
+		}
+	case actionDelete:
+		m.currentPage = pageMessages
+		return func() tea.Msg {
+			return m.deleteMessage(msg)
+		}
+	case actionBrowser:
+		m.currentPage = pageMessages
+		return func() tea.Msg {
+			link, err := m.getPermalink(msg)
+			if err != nil {
+				return actionResultMsg{text: tr("error.get_link"), err: err}
+			}
+			if err := openURL(link); err != nil {
+				return actionResultMsg{text: tr("error.open_browser"), err: err}
+			}
+			return actionResultMsg{text: tr("toast.opened")}
+		}
+	}
+	return nil
+}
+
+// Switch to the compose page to reply to or edit a message
+func (m *Model) startCompose(mode string, msg SlackMessage) {
+	m.composeMode = mode
+	m.composeMessage = msg
+	m.composeQuote = ""
+	m.composeReturn = pageMessages
+	m.quickNote = false
+	m.composeAgain = false
+	m.composeAsPersona = m.personaAvailable()
+	m.composeInput.Reset()
+	if mode == composeEdit {
+		m.composeInput.SetValue(msg.Content)
+	} else {
+		m.restoreDraft()
+	}
+	m.composeInput.Focus()
+	m.currentPage = pageCompose
+}
+
+// Compose a new message to the selected channel from the menu. With none selected,
+// the message names its channel itself, e.g. "#general hello".
+func (m *Model) openComposeMessage() tea.Cmd {
+	target := SlackMessage{}
+	if m.selectedChannelID != "" {
+		target = SlackMessage{Channel: m.channelName(m.selectedChannelID), ChannelID: m.selectedChannelID}
+	}
+
+	m.startCompose(composeNew, target)
+	m.composeReturn = pageMain
+	m.composeAgain = m.config.AfterSend == afterSendCompose
+	return nil
+}
+
+// Move on from a sent preset message the way the config asks: back to the menu,
+// to the channel to see the message land, or to compose for a follow-up
+func (m *Model) afterPresetSent(channelID string) tea.Cmd {
+	switch m.config.AfterSend {
+	case afterSendChannel:
+		return m.showChannel(channelID)
+	case afterSendCompose:
+		m.startCompose(composeNew, SlackMessage{Channel: m.channelName(channelID), ChannelID: channelID})
+		m.composeReturn = pageMain
+		m.composeAgain = true
+		return nil
+	}
+	m.currentPage = pageMain
+	return m.fetchMessages
+}
+
+// The confirmation for a sent preset, with the time Slack gave the message
+func (m Model) sentText(msg messageSentMsg) string {
+	sent := parseSlackTimestamp(msg.timestamp)
+	if sent.IsZero() {
+		return fmt.Sprintf(tr("toast.sent_to"), m.channelName(msg.channelID))
+	}
+	return fmt.Sprintf(tr("toast.sent_at"), m.channelName(msg.channelID), sent.In(m.configuredLocation()).Format("15:04"))
+}
+
+// Start the quick-note compose for the configured channel, if it exists
+func (m *Model) startQuickNote() tea.Cmd {
+	if ch, ok := m.findChannel(m.config.QuickNote.Channel); ok {
+		m.startCompose(composeNew, SlackMessage{Channel: ch.Name, ChannelID: ch.ID})
+		m.composeReturn = pageMain
+		m.quickNote = true
+		m.composeAgain = true
+		return nil
+	}
+	return m.showToast(fmt.Sprintf(tr("toast.quick_note_unknown"), m.config.QuickNote.Channel), true)
+}
+
+// Prefix every line of the text with "> " so Slack renders it as a blockquote
+func quoteText(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = "> " + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Send the composed text as a reply or as the new text of an edited message
+func (m *Model) submitCompose(text string) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	msg := m.composeMessage
+	draft := composeDraftKey(m.composeMode, msg)
+
+	// A new message can name its own channel, e.g. "#general hello", and must without one selected
+	if target, rest, ok := composeTarget(text); ok && m.composeMode == composeNew {
+		ch, found := m.findChannel(target)
+		if !found {
+			return actionResultMsg{text: tr("error.send_message"), err: fmt.Errorf(tr("compose.unknown_channel"), target)}
+		}
+		msg.Channel, msg.ChannelID = ch.Name, ch.ID
+		text = rest
+	} else if m.composeMode == composeNew && msg.ChannelID == "" {
+		return actionResultMsg{text: tr("error.send_message"), err: errors.New(tr("compose.need_channel"))}
+	}
+
+	if m.composeQuote != "" {
+		text = quoteText(m.composeQuote) + text
+	}
+
+	switch m.composeMode {
+	case composeNew:
+		if _, err := m.sendMessage(msg.ChannelID, text, m.senderOptions()...); err != nil {
+			return actionResultMsg{text: tr("error.send_message"), err: err}
+		}
+		return actionResultMsg{text: fmt.Sprintf(tr("toast.sent_to"), msg.Channel), draft: draft, channelID: msg.ChannelID}
+	case composeChannel:
+		if _, err := m.sendMessage(msg.ChannelID, text, m.senderOptions()...); err != nil {
+			return actionResultMsg{text: tr("error.send_reply"), err: err}
+		}
+		return actionResultMsg{text: tr("toast.reply_sent"), refresh: true, draft: draft}
+	case composeReply:
+		if _, err := m.sendMessage(msg.ChannelID, text, append([]slack.MsgOption{slack.MsgOptionTS(msg.threadRoot())}, m.senderOptions()...)...); err != nil {
+			return actionResultMsg{text: tr("error.send_reply"), err: err}
+		}
+		return actionResultMsg{text: tr("toast.reply_sent"), refresh: true, draft: draft}
+	case composeEdit:
+		_, _, _, err := m.slackClient.UpdateMessage(
+			msg.ChannelID,
+			msg.Timestamp,
+			slack.MsgOptionText(text, false),
+		)
+		if err != nil {
+			return actionResultMsg{text: tr("error.edit_message"), err: err}
+		}
+		return actionResultMsg{text: tr("toast.edited"), refresh: true}
+	}
+
+	return actionResultMsg{text: tr("error.send_message"), err: fmt.Errorf(tr("error.compose_mode"), m.composeMode)}
+}
+
+// Whether messages can be posted under the configured persona. Slack only
+// honors a custom username and icon for bot tokens.
+func (m Model) personaAvailable() bool {
+	return m.botToken && (m.config.Persona.Username != "" || m.config.Persona.IconEmoji != "")
+}
+
+// Options that set who a composed message is posted as
+func (m Model) senderOptions() []slack.MsgOption {
+	if !m.composeAsPersona {
+		return []slack.MsgOption{slack.MsgOptionAsUser(true)}
+	}
+
+	var options []slack.MsgOption
+	if m.config.Persona.Username != "" {
+		options = append(options, slack.MsgOptionUsername(m.config.Persona.Username))
+	}
+	if m.config.Persona.IconEmoji != "" {
+		options = append(options, slack.MsgOptionIconEmoji(m.config.Persona.IconEmoji))
+	}
+	return options
+}
+
+// Add an emoji reaction to a message
+func (m *Model) addReaction(name string, msg SlackMessage) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	err := m.slackClient.AddReaction(name, slack.NewRefToMessage(msg.ChannelID, msg.Timestamp))
+	if err != nil {
+		return actionResultMsg{text: tr("error.add_reaction"), err: err}
+	}
+
+	return reactionAddedMsg{channelID: msg.ChannelID, timestamp: msg.Timestamp, name: name}
+}
+
+// Remove one of the user's reactions from a message. A reaction that's
+// already gone, e.g. removed from another client, counts as removed.
+func (m *Model) removeReaction(name string, msg SlackMessage) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	err := m.slackClient.RemoveReaction(name, slack.NewRefToMessage(msg.ChannelID, msg.Timestamp))
+	if err != nil && err.Error() == "no_reaction" {
+		return reactionRemovedMsg{channelID: msg.ChannelID, timestamp: msg.Timestamp, name: name, alreadyGone: true}
+	}
+	return reactionRemovedMsg{channelID: msg.ChannelID, timestamp: msg.Timestamp, name: name, err: err}
+}
+
+// Names of the reactions on a message that include the user's own
+func (m Model) ownReactions(msg SlackMessage) []string {
+	var names []string
+	for _, reaction := range msg.Reactions {
+		for _, user := range reaction.Users {
+			if user == m.userID {
+				names = append(names, reaction.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// Put the user on a reaction in the loaded messages, so the chip shows without a refetch
+func (m *Model) addOwnReaction(channelID, timestamp, name string) {
+	for i, msg := range m.messages {
+		if msg.ChannelID != channelID || msg.Timestamp != timestamp {
+			continue
+		}
+		if slices.Contains(m.ownReactions(msg), name) {
+			continue
+		}
+
+		reactions := slices.Clone(msg.Reactions)
+		found := false
+		for j, reaction := range reactions {
+			if reaction.Name == name {
+				reactions[j].Count++
+				reactions[j].Users = append(slices.Clone(reaction.Users), m.userID)
+				found = true
+			}
+		}
+		if !found {
+			reactions = append(reactions, slack.ItemReaction{Name: name, Count: 1, Users: []string{m.userID}})
+		}
+		m.messages[i].Reactions = reactions
+	}
+}
+
+// Take the user off a reaction in the loaded messages, dropping the chip once nobody is left on it
+func (m *Model) dropOwnReaction(channelID, timestamp, name string) {
+	for i, msg := range m.messages {
+		if msg.ChannelID != channelID || msg.Timestamp != timestamp {
+			continue
+		}
+
+		var reactions []slack.ItemReaction
+		for _, reaction := range msg.Reactions {
+			if reaction.Name == name {
+				var users []string
+				for _, user := range reaction.Users {
+					if user != m.userID {
+						users = append(users, user)
+					}
+				}
+				if len(users) < len(reaction.Users) {
+					reaction.Count--
+				}
+				reaction.Users = users
+				if reaction.Count <= 0 {
+					continue
+				}
+			}
+			reactions = append(reactions, reaction)
+		}
+		m.messages[i].Reactions = reactions
+	}
+}
+
+// Pin a message to its channel
+func (m *Model) pinMessage(msg SlackMessage) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	err := m.slackClient.AddPin(msg.ChannelID, slack.NewRefToMessage(msg.ChannelID, msg.Timestamp))
+	if err != nil {
+		return actionResultMsg{text: tr("error.pin_message"), err: err}
+	}
+
+	return actionResultMsg{text: tr("toast.pinned")}
+}
+
+// Delete one of the user's own messages
+func (m *Model) deleteMessage(msg SlackMessage) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	_, _, err := m.slackClient.DeleteMessage(msg.ChannelID, msg.Timestamp)
+	if err != nil {
+		return actionResultMsg{text: tr("error.delete_message"), err: err}
+	}
+
+	return actionResultMsg{text: tr("toast.deleted"), refresh: true}
+}
+
+// Look up the permalink for a message
+func (m *Model) getPermalink(msg SlackMessage) (string, error) {
+	if m.slackClient == nil {
+		return "", errors.New(tr("error.client_missing"))
+	}
+
+	return m.slackClient.GetPermalink(&slack.PermalinkParameters{
+		Channel: msg.ChannelID,
+		Ts:      msg.Timestamp,
+	})
+}
+
+// Open a message's text in $PAGER, or $EDITOR if there's no pager, handing the
+// terminal over until it exits
+func openInPager(msg SlackMessage) tea.Cmd {
+	f, err := os.CreateTemp("", "lazyslackui-*.txt")
+	if err != nil {
+		return func() tea.Msg { return actionResultMsg{text: tr("error.open_pager"), err: err} }
+	}
+	_, err = f.WriteString(slackUnescaper.Replace(msg.Content) + "\n")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return func() tea.Msg { return actionResultMsg{text: tr("error.open_pager"), err: err} }
+	}
+
+	args := pagerArgs()
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		os.Remove(f.Name())
+		if err != nil {
+			return actionResultMsg{text: tr("error.open_pager"), err: err}
+		}
+		return nil
+	})
+}
+
+// The program to read text in: $PAGER, else $EDITOR, else less. The variables may
+// carry arguments, e.g. "less -R"; blank ones are skipped.
+func pagerArgs() []string {
+	for _, program := range []string{os.Getenv("PAGER"), os.Getenv("EDITOR")} {
+		if args := strings.Fields(program); len(args) > 0 {
+			return args
+		}
+	}
+	return []string{"less"}
+}
+
+// Copy text to the system clipboard
+func copyToClipboard(text, confirmation string) tea.Msg {
+	if err := clipboard.WriteAll(text); err != nil {
+		return actionResultMsg{text: tr("error.clipboard"), err: err}
+	}
+	return actionResultMsg{text: confirmation}
+}
+
+// Pull the contents of ``` fenced code blocks out of a message, ignoring an unterminated fence
+func extractCodeBlocks(text string) []string {
+	var blocks []string
+
+	parts := strings.Split(text, "```")
+	for i := 1; i < len(parts)-1; i += 2 {
+		block := strings.Trim(parts[i], "\n")
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		blocks = append(blocks, slackUnescaper.Replace(block))
+	}
+
+	return blocks
+}
+
+// Undo the HTML entity escaping Slack applies to message text
+var slackUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// First line of a code block, shortened for the picker
+func codeBlockPreview(block string) string {
+	line := strings.TrimSpace(strings.SplitN(block, "\n", 2)[0])
+	if len([]rune(line)) > 40 {
+		line = string([]rune(line)[:40]) + "…"
+	}
+	return line
+}
+
+// Fetch every member ID of a channel, following the pagination cursor
+func (m *Model) fetchChannelMembers(channelID string) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	var members []string
+	cursor := ""
+	for {
+		ids, next, err := m.slackClient.GetUsersInConversation(&slack.GetUsersInConversationParameters{
+			ChannelID: channelID,
+			Cursor:    cursor,
+			Limit:     200,
+		})
+		if err != nil {
+			return actionResultMsg{text: tr("error.channel_members"), err: err}
+		}
+		members = append(members, ids...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	return channelMembersMsg{channelID: channelID, members: members}
+}
+
+// Open the member picker for the from-user filter, listing members with loaded messages first
+func (m *Model) openMemberPicker(channelID string, members []string) tea.Cmd {
+	// Resolve names from the people cache and the authors of loaded messages
+	names := make(map[string]string)
+	for _, user := range m.users {
+		names[user.ID] = user.Name
+	}
+	posted := make(map[string]bool)
+	for _, msg := range m.messages {
+		if msg.ChannelID == channelID && msg.UserID != "" {
+			names[msg.UserID] = msg.User
+			posted[msg.UserID] = true
+		}
+	}
+
+	var active, rest []list.Item
+	for _, id := range members {
+		name, ok := names[id]
+		if !ok {
+			name = id
+		}
+		item := QuickAction{id: id, name: "@" + name}
+		if posted[id] {
+			active = append(active, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+
+	m.fromUserChannelID = channelID
+	m.memberOptions.ResetFilter()
+	_, height := m.chromeContentSize()
+	m.memberOptions.SetHeight(min(len(members)+4, height))
+	m.memberOptions.Select(0)
+	m.currentPage = pageMembers
+	return m.memberOptions.SetItems(append(active, rest...))
+}
+
+// Files in a message that are still accessible
+func openableFiles(files []slack.File) []slack.File {
+	var openable []slack.File
+	for _, f := range files {
+		if fileRestriction(f) == "" && fileURL(f) != "" {
+			openable = append(openable, f)
+		}
+	}
+	return openable
+}
+
+// Why a file can't be viewed, or "" if it can
+func fileRestriction(f slack.File) string {
+	switch {
+	case f.Mode == "hidden_by_limit":
+		return tr("files.hidden_by_limit")
+	case f.Mode == "tombstone":
+		return tr("files.deleted")
+	case f.URLPrivate == "" && f.Permalink == "" && !f.IsExternal:
+		return tr("files.restricted")
+	}
+	return ""
+}
+
+// Best URL for opening a file in the browser
+func fileURL(f slack.File) string {
+	if f.Permalink != "" {
+		return f.Permalink
+	}
+	return f.URLPrivate
+}
+
+// Display name for a file
+func fileName(f slack.File) string {
+	if f.Name != "" {
+		return f.Name
+	}
+	if f.Title != "" {
+		return f.Title
+	}
+	return f.ID
+}
+
+// Open a file's URL in the browser
+func openFileCmd(f slack.File) tea.Cmd {
+	return func() tea.Msg {
+		if err := openURL(fileURL(f)); err != nil {
+			return actionResultMsg{text: tr("error.open_file"), err: err}
+		}
+		return actionResultMsg{text: fmt.Sprintf(tr("toast.file_opened"), fileName(f))}
+	}
+}
+
+// Human-readable file size, e.g. "24 KB"
+func formatFileSize(size int) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%d KB", size/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+// Open a URL with the platform's default handler
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// Show a transient notification that dismisses itself after a few seconds
+func (m *Model) showToast(text string, isError bool) tea.Cmd {
+	return m.showToastFor(text, isError, toastDuration)
+}
+
+// Summarize the session just connected, so it's clear it's the right workspace and user
+func (m *Model) showStartupBanner() tea.Cmd {
+	workspace := m.teamDomain
+	if workspace == "" {
+		workspace = tr("status.unknown")
+	}
+	mode := tr("banner.user_token")
+	if m.botToken {
+		mode = tr("banner.bot_token")
+	}
+	if m.rtm != nil {
+		mode = tr("banner.live") + ", " + mode
+	}
+
+	cmd := m.showToastFor(fmt.Sprintf(tr("banner.session"), workspace, m.userName, len(m.channels), mode), false, bannerDuration)
+	m.bannerToastID = m.toastID
+	return cmd
+}
+
+// Show a transient notification for the given time
+func (m *Model) showToastFor(text string, isError bool, duration time.Duration) tea.Cmd {
+	m.toastID++
+	m.toast = text
+	m.toastIsError = isError
+
+	id := m.toastID
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return clearToastMsg{id: id}
+	})
+}
+
+// Messages currently shown in the viewport, after any view filters
+func (m Model) visibleMessages() []SlackMessage {
+	var visible []SlackMessage
+	for _, msg := range m.messages {
+		if m.fromUserID != "" && (msg.UserID != m.fromUserID || msg.ChannelID != m.fromUserChannelID) {
+			continue
+		}
+		if !m.showMuted && m.isMuted(msg) {
+			continue
+		}
+		if !m.matchesSearch(msg) {
+			continue
+		}
+		visible = append(visible, msg)
+	}
+
+	if !m.newestFirst {
+		return visible
+	}
+
+	reversed := make([]SlackMessage, len(visible))
+	for i, msg := range visible {
+		reversed[len(visible)-1-i] = msg
+	}
+	return reversed
+}
+
+// Whether a message is from someone on the mute list, matched by user ID or handle
+func (m Model) isMuted(msg SlackMessage) bool {
+	for _, muted := range m.config.MutedUsers {
+		muted = strings.TrimPrefix(muted, "@")
+		if muted == msg.UserID || strings.EqualFold(muted, msg.User) {
+			return true
+		}
+	}
+	return false
+}
+
+// Number of loaded messages the mute list is hiding
+func (m Model) mutedCount() int {
+	count := 0
+	for _, msg := range m.messages {
+		if m.isMuted(msg) {
+			count++
+		}
+	}
+	return count
+}
+
+// The selected message, if there is one
+func (m Model) selectedMsg() (SlackMessage, bool) {
+	visible := m.visibleMessages()
+	if m.selectedMessage < 0 || m.selectedMessage >= len(visible) {
+		return SlackMessage{}, false
+	}
+	return visible[m.selectedMessage], true
+}
+
+// Re-render the viewport after the messages or filters change, keeping the selection in range
+func (m *Model) refreshMessages() {
+	visible := m.visibleMessages()
+	if m.selectedMessage >= len(visible) {
+		m.selectedMessage = len(visible) - 1
+	}
+	if m.selectedMessage < 0 {
+		m.selectedMessage = 0
+	}
+
+	// The thread view has the viewport to itself until it's closed
+	if m.currentPage == pageThread {
+		return
+	}
+
+	m.viewport.SetContent(m.formatMessages())
+}
+
+// Remember the newest message seen in each channel for the next catch-up divider
+func (m *Model) rememberSeen() {
+	for _, message := range m.messages {
+		if message.Timestamp > m.state.LastSeen[message.ChannelID] {
+			m.state.LastSeen[message.ChannelID] = message.Timestamp
+		}
+	}
+}
+
+// Move the message selection and keep the selected message in view
+func (m *Model) moveSelection(delta int) {
+	if len(m.visibleMessages()) == 0 {
+		return
+	}
+
+	m.selectedMessage += delta
+	m.following = false
+	m.refreshMessages()
+	m.scrollToSelected()
+}
+
+// Scroll the viewport by the configured number of lines for PageUp/PageDown,
+// reporting whether the key was one of them. Without a setting the viewport
+// keeps scrolling a screenful at a time.
+func (m *Model) scrollPage(msg tea.KeyMsg) bool {
+	if m.config.ScrollLines <= 0 {
+		return false
+	}
+	switch {
+	case key.Matches(msg, m.viewport.KeyMap.PageUp):
+		m.viewport.ScrollUp(m.config.ScrollLines)
+	case key.Matches(msg, m.viewport.KeyMap.PageDown):
+		m.viewport.ScrollDown(m.config.ScrollLines)
+	default:
+		return false
+	}
+	return true
+}
+
+// Lines the mouse wheel scrolls the viewport, falling back to the viewport's own default
+func wheelLines(cfg Config) int {
+	if cfg.ScrollLines > 0 {
+		return cfg.ScrollLines
+	}
+	return defaultWheelLines
+}
+
+// Scroll the viewport just enough to show the whole selected message
+func (m *Model) scrollToSelected() {
+	visible := m.visibleMessages()
+	if len(visible) == 0 {
+		return
+	}
+
+	dividers := m.catchUpDividers(visible)
+	top := 0
+	for i := 0; i < m.selectedMessage; i++ {
+		top += lipgloss.Height(m.formatMessage(i, visible, dividers))
+	}
+	bottom := top + lipgloss.Height(m.formatMessage(m.selectedMessage, visible, dividers))
+
+	height := m.viewport.Height - m.viewport.Style.GetVerticalFrameSize()
+	if top < m.viewport.YOffset {
+		m.viewport.SetYOffset(top)
+	} else if bottom > m.viewport.YOffset+height {
+		m.viewport.SetYOffset(bottom - height)
+	}
+}
+
+// Whether the current page is taking text input, so global keys shouldn't fire
+func (m Model) isTyping() bool {
+	switch m.currentPage {
+	case pageCompose:
+		return true
+	case pageMain:
+		return m.quickActions.FilterState() == list.Filtering
+	case pageSetStatus:
+		return m.statusOptions.FilterState() == list.Filtering
+	case pagePresetMessage:
+		return m.presetMessages.FilterState() == list.Filtering
+	case pagePeople:
+		return m.people.FilterState() == list.Filtering
+	case pageMentions:
+		return m.mentions.FilterState() == list.Filtering
+	case pageSettings:
+		return m.editingSetting != "" || m.settingsList.FilterState() == list.Filtering
+	case pageRoster:
+		return m.roster.FilterState() == list.Filtering
+	case pageChannelList:
+		// The picker's filter takes every key it doesn't use to move or choose
+		return true
+	case pageDownloads:
+		return m.downloadList.FilterState() == list.Filtering
+	case pageMembers:
+		return m.memberOptions.FilterState() == list.Filtering
+	case pageMessages:
+		return m.searching
+	}
+	return false
+}
+
+// The status after the current one in the configured cycle, skipping unknown entries
+func (m Model) nextCycleStatus() string {
+	var cycle []string
+	for _, status := range m.config.StatusCycle {
+		if validPresence(status) {
+			cycle = append(cycle, status)
+		}
+	}
+	if len(cycle) == 0 {
+		cycle = defaultConfig().StatusCycle
+	}
+
+	for i, status := range cycle {
+		if status == m.userStatus {
+			return cycle[(i+1)%len(cycle)]
+		}
+	}
+	return cycle[0]
+}
+
+// Whether a status is one the app knows how to set
+func validPresence(p string) bool {
+	switch p {
+	case statusActive, statusAway, statusDND:
+		return true
+	default:
+		return false
+	}
+}
+
+// Display label for a status
+func statusLabel(status string) string {
+	switch status {
+	case statusActive:
+		return tr("status.active")
+	case statusAway:
+		return tr("status.away")
+	case statusDND:
+		return tr("status.dnd")
+	default:
+		return tr("status.unknown")
+	}
+}
+
+// The value users.setPresence takes for a status, which knows only "auto" (active)
+// and "away". DND has none, so setting it leaves the presence as it is.
+func slackPresence(status string) (string, bool) {
+	switch status {
+	case statusActive:
+		return "auto", true
+	case statusAway:
+		return "away", true
+	default:
+		return "", false
+	}
+}
+
+// The page that esc/q returns to from the given page
+func (m Model) backPage(page string) string {
+	switch page {
+	case pageCompose:
+		return m.composeReturn
+	case pageStatusClear:
+		return pageSetStatus
+	case pageChannelInfo:
+		return pageChannelList
+	case pageMessageMenu, pageReactions, pageCodeBlocks, pageFiles, pageMembers, pageRoster, pageLinks, pageThread, pageUnreact, pageReactors, pageDownloads:
+		return pageMessages
+	default:
+		return pageMain
+	}
+}
+
+// Go back from the current page, restoring the message list when leaving a thread
+func (m *Model) goBack() {
+	leaving := m.currentPage
+	m.currentPage = m.backPage(leaving)
+	if leaving == pageCompose {
+		m.stashDraft()
+	}
+	if leaving == pageThread {
+		m.refreshMessages()
+		m.scrollToSelected()
+	}
+}
+
+// Custom messages for our application
+type initMsg struct {
+	workspace  string
+	client     SlackAPI
+	rtm        *slack.RTM
+	userID     string
+	userName   string
+	teamDomain string
+	botToken   bool
+	channels   []slack.Channel
+
+	// Direct messages with people who still have accounts
+	directMessages []slack.Channel
+}
+
+type messagesMsg struct {
+	channelID string
+	messages  []SlackMessage
+
+	// Cursor for the page of the channel's history before these, empty at its beginning
+	cursor string
+
+	// Channels of the combined view whose messages couldn't be fetched
+	failed []string
+}
+
+type statusUpdatedMsg struct {
+	status     string
+	label      string
+	expiration int64
+	cleared    bool
+}
+
+type messageSentMsg struct {
+	channelID string
+	timestamp string
+	text      string
+}
+
+type actionResultMsg struct {
+	text    string
+	err     error
+	refresh bool
+
+	// Draft the sent message came from, to drop once it's sent
+	draft string
+
+	// Channel a new message went to, which a leading "#channel" may have changed
+	channelID string
+}
+
+type channelMembersMsg struct {
+	channelID string
+	members   []string
+}
+
+type markReadTickMsg struct {
+	seq       int
+	channelID string
+}
+
+type channelMarkedMsg struct {
+	channelID string
+	manual    bool
+	err       error
+}
+
+type reactionAddedMsg struct {
+	channelID string
+	timestamp string
+	name      string
+}
+
+type reactionRemovedMsg struct {
+	channelID   string
+	timestamp   string
+	name        string
+	alreadyGone bool
+	err         error
+}
+
+type clearToastMsg struct {
+	id int
+}
+
+// Redraws the view so relative times like "2 minutes ago" stay current
+type clockTickMsg struct{}
+
+// Wait for the next relative-time redraw
+func clockTick() tea.Cmd {
+	return tea.Tick(time.Minute, func(time.Time) tea.Msg {
+		return clockTickMsg{}
+	})
+}
+
+// Initialize the application
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(
+		spinner.Tick,
+		m.initSlackClient,
+		m.reportConfigWarnings,
+		m.scheduleTick(),
+		m.idleTick(),
+		clockTick(),
+	)
+}
+
+// Surface problems found in the config file without stopping the app
+func (m Model) reportConfigWarnings() tea.Msg {
+	if len(m.configWarnings) == 0 {
+		return nil
+	}
+	return actionResultMsg{text: tr("error.config"), err: errors.New(strings.Join(m.configWarnings, "; "))}
+}
+
+// Update the application state based on messages
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Any keypress or click counts as activity for auto-away
+	var inputCmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		inputCmd = m.noteInput()
+	case tea.MouseMsg:
+		if msg.Action == tea.MouseActionPress {
+			inputCmd = m.noteInput()
+		}
+	}
+
+	model, cmd := m.handleMsg(msg)
+
+	// Follow whichever channel the update left in focus
+	updated := model.(Model)
+	focusCmd := updated.updateFocus()
+	return updated, tea.Batch(inputCmd, cmd, focusCmd)
+}
+
+// Handle a single message, returning the new state
+func (m Model) handleMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// Any key dismisses the startup banner
+		if m.bannerToastID != 0 && m.bannerToastID == m.toastID {
+			m.toast = ""
+		}
+		m.bannerToastID = 0
+
+		// The quit prompt takes every key until it's answered
+		if m.confirmingQuit {
+			switch msg.String() {
+			case "y", "enter", "ctrl+c":
+				return m, tea.Quit
+			case "n", "esc":
+				m.confirmingQuit = false
+			}
+			return m, nil
+		}
+
+		// A timed-out request can be tried again from its error
+		if m.error != "" && m.retry != nil && msg.String() == "r" {
+			return m, m.retryTimedOut()
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q":
+			// Let "q" be typed while entering text
+			if msg.String() == "q" && m.isTyping() {
+				break
+			}
+			if m.currentPage == pageMain {
+				return m, m.requestQuit()
 			} else {
-				m.currentPage = pageMain
+				m.goBack()
 				return m, nil
 			}
+		case m.config.Keys.CycleStatus:
+			if !m.isTyping() && m.slackClient != nil {
+				next := m.nextCycleStatus()
+				return m, func() tea.Msg {
+					return m.setStatus(m.statusForPresence(next), 0)
+				}
+			}
+		case m.config.Keys.ReloadConfig:
+			if !m.isTyping() {
+				return m, m.reloadConfig()
+			}
+		case m.config.Keys.RefreshUsers:
+			if !m.isTyping() && m.slackClient != nil {
+				return m, tea.Batch(m.showToast(tr("toast.users_loading"), false), m.prefetchUsers(true))
+			}
+		case m.config.Keys.FocusMode:
+			if !m.isTyping() && (m.currentPage == pageMessages || m.currentPage == pageThread) {
+				return m, m.toggleFocusMode()
+			}
+		case m.config.Keys.ToggleSchedule:
+			if !m.isTyping() && len(m.config.StatusSchedule) > 0 {
+				return m, m.toggleSchedule()
+			}
 		case "esc":
+			// Let filterable lists clear their filter first
+			if m.currentPage == pagePeople && m.people.FilterState() != list.Unfiltered {
+				break
+			}
+			if m.currentPage == pageMembers && m.memberOptions.FilterState() != list.Unfiltered {
+				break
+			}
+			if m.currentPage == pageMentions && m.mentions.FilterState() != list.Unfiltered {
+				break
+			}
+			if m.currentPage == pageRoster && m.roster.FilterState() != list.Unfiltered {
+				break
+			}
+			if m.currentPage == pageChannelList && m.textInput.Value() != "" {
+				break
+			}
+			// Let the messages page drop its search first
+			if m.currentPage == pageMessages && (m.searching || m.searchQuery != "") {
+				break
+			}
+			if m.currentPage == pageDownloads && m.downloadList.FilterState() != list.Unfiltered {
+				break
+			}
+			// Let the settings page cancel an edit first
+			if m.currentPage == pageSettings && (m.editingSetting != "" || m.settingsList.FilterState() != list.Unfiltered) {
+				break
+			}
 			if m.currentPage != pageMain {
-				m.currentPage = pageMain
+				m.goBack()
 				return m, nil
 			}
 		}
 
+	case tea.MouseMsg:
+		if m.error != "" || m.isLoading {
+			return m, nil
+		}
+		return m, m.handleMouse(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 
-		// Update list dimensions
-		m.quickActions.SetSize(msg.Width-10, msg.Height-headerHeight-footerHeight)
-		m.presetMessages.SetSize(msg.Width-10, msg.Height-headerHeight-footerHeight)
-		m.statusOptions.SetSize(msg.Width-10, msg.Height-headerHeight-footerHeight)
+		// Update list dimensions, which can't go below zero in a tiny terminal
+		listWidth := max(msg.Width-10, 0)
+		listHeight := max(msg.Height-headerHeight-footerHeight, 0)
+		m.quickActions.SetSize(listWidth, listHeight)
+		m.presetMessages.SetSize(listWidth, listHeight)
+		m.statusOptions.SetSize(listWidth, listHeight)
+		m.workspaceList.SetSize(listWidth, listHeight)
+		m.people.SetSize(listWidth, listHeight)
+		m.mentions.SetSize(listWidth, listHeight)
+		m.roster.SetSize(listWidth, listHeight)
+		m.channelList.SetSize(listWidth, max(listHeight-2, 0))
+		m.downloadList.SetSize(listWidth, listHeight)
+		m.settingsList.SetSize(listWidth, max(listHeight-2, 0))
+
+		// Update viewport dimensions, leaving room for the channel pane in the split layout
+		m.applyLayout()
+		m.composeInput.SetWidth(listWidth)
+
+		return m, nil
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case initMsg:
+		// A connection to a workspace since switched away from isn't needed anymore
+		if msg.workspace != m.workspace {
+			msg.rtm.Disconnect()
+			break
+		}
+		m.slackClient = msg.client
+		m.rtm = msg.rtm
+		m.liveState = liveConnected
+		m.userID = msg.userID
+		m.userName = msg.userName
+		m.teamDomain = msg.teamDomain
+		m.botToken = msg.botToken
+		m.channels = msg.channels
+		m.directMessages = msg.directMessages
+		m.isLoading = false
+
+		// Pick up in the channel and on the page the last session was on, unless
+		// starting in a quick note
+		m.selectedChannelID = m.lastChannel()
+		resume := m.state.LastPage == pageMessages && m.config.QuickNote.Channel == ""
+		if resume {
+			cmds = append(cmds, m.showChannel(m.selectedChannelID))
+		} else if m.selectedChannelID != "" {
+			cmds = append(cmds, m.fetchPins(m.selectedChannelID))
+		}
+
+		// Confirm where the session is connected; any warning below replaces it
+		if m.config.StartupBanner {
+			cmds = append(cmds, m.showStartupBanner())
+		}
+
+		// A persona needs a bot token, so say so rather than silently posting as the user
+		if !m.botToken && (m.config.Persona.Username != "" || m.config.Persona.IconEmoji != "") {
+			cmds = append(cmds, m.showToast(tr("toast.persona_needs_bot"), true))
+		}
+		// After initialization, fetch messages unless they should wait until a view is opened,
+		// and follow new ones as they arrive
+		cmds = append(cmds, m.waitForLiveMessage())
+		if !m.config.SkipStartupFetch && !resume {
+			cmds = append(cmds, m.fetchMessages)
+		}
+		if m.config.PrefetchUsers {
+			cmds = append(cmds, m.prefetchUsers(false))
+		}
+
+		if m.config.QuickNote.Channel != "" {
+			cmds = append(cmds, m.startQuickNote())
+		}
+
+	case authErrMsg:
+		// Nothing will work until the token is fixed, so say how to sort it out
+		m.error = msg.Error() + "\n" + tr("error.auth_hint")
+		m.isLoading = false
+
+	case scopeErrMsg:
+		m.error = msg.Error() + "\n" + tr("error.scope_hint")
+		m.isLoading = false
+
+	case rateLimitErrMsg:
+		m.isLoading = false
+		if msg.retry == nil {
+			cmds = append(cmds, m.showToast(msg.Error(), true))
+			break
+		}
+		m.isLoading = true
+		cmds = append(cmds,
+			m.showToast(fmt.Sprintf(tr("error.rate_limited"), msg.retryAfter.Round(time.Second)), true),
+			tea.Tick(msg.retryAfter, func(time.Time) tea.Msg { return msg.retry() }))
+
+	case networkErrMsg:
+		m.isLoading = false
+		if msg.retry == nil {
+			cmds = append(cmds, m.showToast(msg.Error(), true))
+			break
+		}
+		m.isLoading = true
+		cmds = append(cmds,
+			m.showToast(fmt.Sprintf(tr("error.network"), networkRetryDelay), true),
+			tea.Tick(networkRetryDelay, func(time.Time) tea.Msg { return msg.retry() }))
+
+	case genericErrMsg:
+		m.error = msg.Error()
+		m.isLoading = false
+
+	case timeoutErrMsg:
+		m.error = msg.Error() + "\n" + tr("error.timeout_hint")
+		m.isLoading = false
+		m.retry = msg.retry
+
+	case messagesMsg:
+		// A late response for a channel that's no longer selected would overwrite the current one's messages
+		if msg.channelID != m.selectedChannelID {
+			break
+		}
+		m.messages = msg.messages
+		m.historyCursor = msg.cursor
+		m.isLoading = false
+
+		if m.currentPage == pageMessages {
+			m.rememberSeen()
+		}
+
+		// Update viewport with messages, starting on the newest so it's in view,
+		// unless the thread view has the viewport
+		if m.currentPage == pageThread {
+			m.refreshMessages()
+		} else {
+			m.selectNewest()
+		}
+
+		// The rest of the combined view is still worth showing, so only mention the channels missing from it
+		if len(msg.failed) > 0 {
+			cmds = append(cmds, m.showToast(fmt.Sprintf(tr("toast.channels_failed"), "#"+strings.Join(msg.failed, ", #")), true))
+		}
+
+	case statusUpdatedMsg:
+		m.userStatus = msg.status
+		m.isLoading = false
+		if m.currentPage == pageSetStatus || m.currentPage == pageStatusClear {
+			m.currentPage = pageMain
+		}
+		cmds = append(cmds, m.showToast(m.statusSetText(msg), false))
+
+	case messageSentMsg:
+		m.isLoading = false
+		cmds = append(cmds, m.afterPresetSent(msg.channelID), m.showToast(m.sentText(msg), false))
+
+	case actionResultMsg:
+		m.isLoading = false
+		if msg.err == nil && msg.draft != "" {
+			delete(m.state.Drafts, msg.draft)
+		}
+
+		// A quick note either ends the session or clears the way for the next one, as does a message composed from the menu
+		if m.currentPage == pageCompose && m.composeAgain {
+			if msg.err == nil && m.quickNote && m.config.QuickNote.QuitAfterSend {
+				return m, tea.Quit
+			}
+			if msg.err == nil {
+				m.composeInput.Reset()
+				cmds = append(cmds, m.showToast(msg.text, false))
+			} else {
+				cmds = append(cmds, m.showToast(fmt.Sprintf("%s: %v", msg.text, msg.err), true))
+			}
+			break
+		}
+
+		if msg.err != nil {
+			cmds = append(cmds, m.showToast(fmt.Sprintf("%s: %v", msg.text, msg.err), true))
+		} else {
+			cmds = append(cmds, m.showToast(msg.text, false))
+		}
+		if m.currentPage == pageCompose {
+			switch {
+			case msg.err != nil:
+				// Stay with the text, e.g. to correct a mistyped #channel
+			case m.composeMode == composeNew && !m.quickNote && m.config.AfterSend == afterSendChannel:
+				// Open the channel to see the message land
+				cmds = append(cmds, m.showChannel(msg.channelID))
+			default:
+				m.currentPage = m.composeReturn
+			}
+		}
+		if msg.refresh {
+			// Answered mentions drop off the needs-reply list
+			if m.currentPage == pageMentions {
+				cmds = append(cmds, m.fetchMentions)
+			} else {
+				cmds = append(cmds, m.fetchMessages)
+			}
+		}
+
+	case reactorsMsg:
+		m.isLoading = false
+		m.openReactors(msg)
+
+	case channelInfoMsg:
+		cmds = append(cmds, m.handleChannelInfo(msg))
+
+	case olderMessagesMsg:
+		cmds = append(cmds, m.handleOlderMessages(msg))
+
+	case pinsMsg:
+		m.handlePins(msg)
+
+	case channelMembersMsg:
+		m.isLoading = false
+		cmds = append(cmds, m.openMemberPicker(msg.channelID, msg.members))
+
+	case usersPageMsg:
+		cmds = append(cmds, m.handleUsersPage(msg))
+
+	case mentionsMsg:
+		cmds = append(cmds, m.handleMentions(msg))
+
+	case liveMessageMsg:
+		cmds = append(cmds, m.handleLiveMessage(msg))
+
+	case liveFlushMsg:
+		m.flushLiveMessages()
+
+	case liveStateMsg:
+		cmds = append(cmds, m.handleLiveState(msg))
+
+	case usersLoadedMsg:
+		cmds = append(cmds, m.handleUsersLoaded(msg))
+
+	case usersRefreshMsg:
+		if msg.seq == m.usersRefreshSeq && m.slackClient != nil {
+			cmds = append(cmds, m.prefetchUsers(false))
+		}
+
+	case clockTickMsg:
+		cmds = append(cmds, clockTick())
+
+	case threadMsg:
+		m.isLoading = false
+		m.showThread(msg)
+
+	case rosterPageMsg:
+		cmds = append(cmds, m.handleRosterPage(msg))
+
+	case rosterCountMsg:
+		if msg.channelID == m.rosterChannelID {
+			m.rosterCount = msg.count
+			m.roster.Title = m.rosterTitle()
+		}
+
+	case presenceMsg:
+		m.handlePresence(msg)
+		if m.currentPage == pageChannelList {
+			cmds = append(cmds, m.refreshChannelList())
+		}
+
+	case presenceRefreshMsg:
+		cmds = append(cmds, m.handlePresenceRefresh(msg))
+
+	case directNamesMsg:
+		if m.currentPage == pageChannelList {
+			cmds = append(cmds, m.refreshChannelList())
+		}
+
+	case sparklineMsg:
+		m.handleSparkline(msg)
+
+	case unreadMsg:
+		cmds = append(cmds, m.handleUnread(msg))
+
+	case downloadFilesMsg:
+		cmds = append(cmds, m.handleDownloadFiles(msg))
+
+	case downloadedFileMsg:
+		cmds = append(cmds, m.handleDownloadedFile(msg))
+
+	case markReadTickMsg:
+		// Only mark the channel if it stayed focused for the whole delay
+		if msg.seq == m.focusSeq && msg.channelID == m.focusedChannelID {
+			ts := m.latestTimestamp(msg.channelID)
+			if ts == "" {
+				// Messages are still loading, so check again after another delay
+				cmds = append(cmds, m.markReadTick())
+			} else {
+				channelID := msg.channelID
+				cmds = append(cmds, func() tea.Msg {
+					return m.markChannelRead(channelID, ts)
+				})
+			}
+		}
+
+	case reactionAddedMsg:
+		m.addOwnReaction(msg.channelID, msg.timestamp, msg.name)
+		m.refreshMessages()
+		cmds = append(cmds, m.showToast(fmt.Sprintf(tr("toast.reacted"), msg.name), false))
+
+	case reactionRemovedMsg:
+		if msg.err != nil {
+			cmds = append(cmds, m.showToast(fmt.Sprintf("%s: %v", tr("error.remove_reaction"), msg.err), true))
+			break
+		}
+		m.dropOwnReaction(msg.channelID, msg.timestamp, msg.name)
+		m.refreshMessages()
+		toast := fmt.Sprintf(tr("toast.unreacted"), msg.name)
+		if msg.alreadyGone {
+			toast = fmt.Sprintf(tr("toast.reaction_gone"), msg.name)
+		}
+		cmds = append(cmds, m.showToast(toast, false))
+
+	case channelMarkedMsg:
+		if msg.err != nil {
+			cmds = append(cmds, m.showToast(fmt.Sprintf("%s: %v", tr("error.mark_read"), msg.err), true))
+		} else {
+			m.clearUnread(msg.channelID)
+			if msg.manual {
+				cmds = append(cmds, m.showToast(fmt.Sprintf(tr("toast.marked_read"), m.channelName(msg.channelID)), false))
+			}
+		}
+
+	case idleTickMsg:
+		cmds = append(cmds, m.handleIdleTick(msg))
+
+	case autoAwayMsg:
+		if msg.err != nil {
+			// Don't claim to be away if the switch didn't happen
+			if msg.away {
+				m.autoAway = false
+			}
+			cmds = append(cmds, m.showToast(fmt.Sprintf("%s: %v", tr("error.change_presence"), msg.err), true))
+		}
+
+	case scheduleTickMsg:
+		cmds = append(cmds, m.handleScheduleTick(msg))
+
+	case scheduledStatusMsg:
+		if msg.err != nil {
+			cmds = append(cmds, m.showToast(fmt.Sprintf("%s: %v", tr("error.scheduled_status"), msg.err), true))
+		} else {
+			if msg.rule.Presence != "" {
+				m.userStatus = msg.rule.Presence
+			}
+			cmds = append(cmds, m.showToast(fmt.Sprintf(tr("toast.scheduled_status"), msg.rule.label()), false))
+		}
+
+	case clearToastMsg:
+		// Ignore timers from toasts that have since been replaced
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
+	}
+
+	// Handle page-specific updates
+	switch m.currentPage {
+	case pageMain:
+		// A digit chooses the numbered quick action as if it were highlighted and entered
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && m.quickActions.FilterState() != list.Filtering {
+			if n, err := strconv.Atoi(keyMsg.String()); err == nil && n >= 1 && n <= min(len(m.quickActions.Items()), 9) {
+				m.quickActions.Select(n - 1)
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			}
+		}
+
+		var cmd tea.Cmd
+		m.quickActions, cmd = m.quickActions.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Handle selection on main page
+		if _, ok := msg.(tea.KeyMsg); ok {
+			switch msg := msg.(type) {
+			case tea.KeyMsg:
+				if msg.String() == "enter" {
+					i, ok := m.quickActions.SelectedItem().(QuickAction)
+					if ok {
+						switch i.id {
+						case quickViewMessages:
+							m.currentPage = pageMessages
+							m.catchUpMarkers = m.catchUpStart()
+							m.isLoading = true
+							cmds = append(cmds, m.fetchMessages)
+						case quickChannels:
+							cmds = append(cmds, m.openChannels())
+						case quickSetStatus:
+							m.currentPage = pageSetStatus
+						case quickSendPreset:
+							m.currentPage = pagePresetMessage
+						case quickCompose:
+							cmds = append(cmds, m.openComposeMessage())
+						case quickPeople:
+							cmds = append(cmds, m.openPeople())
+						case quickMentions:
+							cmds = append(cmds, m.openMentions())
+						case quickSettings:
+							cmds = append(cmds, m.openSettings())
+						case quickWorkspaces:
+							cmds = append(cmds, m.openWorkspaces())
+						case quickQuit:
+							return m, m.requestQuit()
+						}
+					}
+				}
+			}
+		}
+
+	case pageMessages:
+		// The search takes every key while it's being typed
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && m.searching {
+			cmds = append(cmds, m.updateSearch(keyMsg))
+			return m, tea.Batch(cmds...)
+		}
+
+		// Handle message selection before the viewport sees the keys
+		if keyMsg, ok := msg.(tea.KeyMsg); ok {
+			switch keyMsg.String() {
+			case "/":
+				cmds = append(cmds, m.openSearch())
+				return m, tea.Batch(cmds...)
+			case "esc":
+				m.clearSearch()
+				return m, tea.Batch(cmds...)
+			case "up", "k":
+				cmds = append(cmds, m.loadOlderAtEdge(-1))
+				m.moveSelection(-1)
+				return m, tea.Batch(cmds...)
+			case "down", "j":
+				cmds = append(cmds, m.loadOlderAtEdge(1))
+				m.moveSelection(1)
+				return m, tea.Batch(cmds...)
+			case "p":
+				cmds = append(cmds, m.loadOlderMessages())
+				return m, tea.Batch(cmds...)
+			case "r":
+				// Fetch the latest messages of the same channel again
+				m.isLoading = true
+				cmds = append(cmds, m.fetchMessages)
+				return m, tea.Batch(cmds...)
+			case "P":
+				m.togglePins()
+				return m, tea.Batch(cmds...)
+			case "a":
+				if selected, ok := m.selectedMsg(); ok {
+					m.openMessageMenu(selected)
+				}
+				return m, tea.Batch(cmds...)
+			case "enter":
+				// Open the thread a message starts or belongs to, or the action menu for any other message
+				if selected, ok := m.selectedMsg(); ok {
+					if selected.ReplyCount > 0 || selected.isThreadReply() {
+						cmds = append(cmds, m.openThread(selected))
+					} else {
+						m.openMessageMenu(selected)
+					}
+				}
+				return m, tea.Batch(cmds...)
+			case "G", "end":
+				m.jumpToLatest()
+				return m, tea.Batch(cmds...)
+			case "g", "home":
+				m.jumpToOldest()
+				return m, tea.Batch(cmds...)
+			case "f":
+				// Clear an active from-user filter, or pick a member to filter by
+				if m.fromUserID != "" {
+					m.fromUserID = ""
+					m.refreshMessages()
+					return m, tea.Batch(cmds...)
+				}
+				channelID := m.selectedChannelID
+				if selected, ok := m.selectedMsg(); ok && channelID == "" {
+					channelID = selected.ChannelID
+				}
+				if channelID != "" {
+					m.isLoading = true
+					cmds = append(cmds, func() tea.Msg {
+						return m.fetchChannelMembers(channelID)
+					})
+				}
+				return m, tea.Batch(cmds...)
+			case "m":
+				// List everyone in the focused channel
+				if channelID := m.focusedChannel(); channelID != "" {
+					cmds = append(cmds, m.openRoster(channelID))
+				}
+				return m, tea.Batch(cmds...)
+			case "u":
+				// Mark the focused channel as read up to its newest loaded message
+				if channelID := m.focusedChannel(); channelID != "" {
+					cmds = append(cmds, m.markFocusedRead(channelID))
+				}
+				return m, tea.Batch(cmds...)
+			case "L":
+				cmds = append(cmds, m.toggleLayout())
+				return m, tea.Batch(cmds...)
+			case "<", ">":
+				// Narrow or widen the channel pane
+				step := splitRatioStep
+				if keyMsg.String() == "<" {
+					step = -step
+				}
+				m.resizeSplit(step)
+				return m, tea.Batch(cmds...)
+			case "[", "]":
+				// Move to the previous or next channel
+				delta := 1
+				if keyMsg.String() == "[" {
+					delta = -1
+				}
+				cmds = append(cmds, m.stepChannel(delta))
+				return m, tea.Batch(cmds...)
+			case "i":
+				// Show or hide the selected message's full details
+				if selected, ok := m.selectedMsg(); ok {
+					if key := selected.ChannelID + selected.Timestamp; m.detailKey != key {
+						m.detailKey = key
+					} else {
+						m.detailKey = ""
+					}
+					m.refreshMessages()
+					m.scrollToSelected()
+				}
+				return m, tea.Batch(cmds...)
+			case "v":
+				// Read the selected message in a pager
+				if selected, ok := m.selectedMsg(); ok {
+					cmds = append(cmds, openInPager(selected))
+				}
+				return m, tea.Batch(cmds...)
+			case "D":
+				// Save every file shared in the focused channel's recent history
+				if channelID := m.focusedChannel(); channelID != "" && m.slackClient != nil {
+					cmds = append(cmds, m.startDownload(channelID))
+				}
+				return m, tea.Batch(cmds...)
+			case "x":
+				// Remove one of the user's reactions, asking which if there are several
+				if selected, ok := m.selectedMsg(); ok {
+					own := m.ownReactions(selected)
+					if len(own) == 1 {
+						cmds = append(cmds, func() tea.Msg {
+							return m.removeReaction(own[0], selected)
+						})
+					} else if len(own) > 1 {
+						items := make([]list.Item, len(own))
+						for i, name := range own {
+							items[i] = QuickAction{id: name, name: ":" + name + ":"}
+						}
+						m.unreactOptions.SetItems(items)
+						m.unreactOptions.SetHeight(len(items) + 4)
+						m.unreactOptions.Select(0)
+						m.currentPage = pageUnreact
+					}
+				}
+				return m, tea.Batch(cmds...)
+			case "+":
+				// Go straight to the reaction picker, skipping the action menu
+				if _, ok := m.selectedMsg(); ok {
+					m.reactionOptions.Select(0)
+					m.currentPage = pageReactions
+				}
+				return m, tea.Batch(cmds...)
+			case "O":
+				// Open the selected message's file in the browser, or pick which one
+				if selected, ok := m.selectedMsg(); ok {
+					if len(openableFiles(selected.Files)) == 0 {
+						cmds = append(cmds, m.showToast(tr("toast.no_files"), false))
+					} else {
+						cmds = append(cmds, m.runMessageAction(actionFile, selected))
+					}
+				}
+				return m, tea.Batch(cmds...)
+			case "w":
+				// List who reacted with each emoji
+				if selected, ok := m.selectedMsg(); ok {
+					if len(selected.Reactions) == 0 {
+						cmds = append(cmds, m.showToast(tr("toast.no_reactions"), false))
+					} else {
+						m.isLoading = true
+						cmds = append(cmds, m.fetchReactors(selected))
+					}
+				}
+				return m, tea.Batch(cmds...)
+			case "o":
+				// Flip the order, keeping the same message selected
+				m.newestFirst = !m.newestFirst
+				m.selectedMessage = len(m.visibleMessages()) - 1 - m.selectedMessage
+				m.refreshMessages()
+				m.scrollToSelected()
+				return m, tea.Batch(cmds...)
+			case "M":
+				// Reveal or hide again the messages from muted users, keeping the selection if it stays visible
+				selected, hadSelection := m.selectedMsg()
+				m.showMuted = !m.showMuted
+				m.selectedMessage = 0
+				if hadSelection {
+					for i, msg := range m.visibleMessages() {
+						if msg.ChannelID == selected.ChannelID && msg.Timestamp == selected.Timestamp {
+							m.selectedMessage = i
+							break
+						}
+					}
+				}
+				m.refreshMessages()
+				m.scrollToSelected()
+				return m, tea.Batch(cmds...)
+			case "H":
+				// Temporarily show the subtypes hidden by the config
+				m.showAllSubtypes = !m.showAllSubtypes
+				toast := tr("toast.subtypes_filtered")
+				if m.showAllSubtypes {
+					toast = tr("toast.subtypes_all")
+				}
+				m.isLoading = true
+				cmds = append(cmds, m.showToast(toast, false), m.fetchMessages)
+				return m, tea.Batch(cmds...)
+			}
+		}
+
+		// Handle viewport scrolling
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && m.scrollPage(keyMsg) {
+			m.following = m.following && m.atNewest()
+			return m, tea.Batch(cmds...)
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		m.following = m.following && m.atNewest()
+		cmds = append(cmds, cmd)
+
+	case pageMessageMenu:
+		var cmd tea.Cmd
+		m.messageActions, cmd = m.messageActions.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Handle action selection
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			i, ok := m.messageActions.SelectedItem().(QuickAction)
+			if selected, found := m.selectedMsg(); ok && found {
+				cmds = append(cmds, m.runMessageAction(i.id, selected))
+			}
+		}
+
+	case pageReactions:
+		var cmd tea.Cmd
+		m.reactionOptions, cmd = m.reactionOptions.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Handle reaction selection
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			i, ok := m.reactionOptions.SelectedItem().(ReactionOption)
+			if selected, found := m.selectedMsg(); ok && found {
+				m.currentPage = pageMessages
+				cmds = append(cmds, func() tea.Msg {
+					return m.addReaction(i.name, selected)
+				})
+			}
+		}
+
+	case pageCodeBlocks:
+		var cmd tea.Cmd
+		m.codeBlocks, cmd = m.codeBlocks.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Handle code block selection
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			i, ok := m.codeBlocks.SelectedItem().(QuickAction)
+			if ok {
+				m.currentPage = pageMessages
+				cmds = append(cmds, func() tea.Msg {
+					return copyToClipboard(i.description, tr("toast.code_copied"))
+				})
+			}
+		}
+
+	case pageFiles:
+		var cmd tea.Cmd
+		m.fileOptions, cmd = m.fileOptions.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Handle file selection
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			i, ok := m.fileOptions.SelectedItem().(QuickAction)
+			if selected, found := m.selectedMsg(); ok && found {
+				for _, f := range selected.Files {
+					if f.ID == i.id {
+						m.currentPage = pageMessages
+						cmds = append(cmds, openFileCmd(f))
+						break
+					}
+				}
+			}
+		}
+
+	case pageUnreact:
+		var cmd tea.Cmd
+		m.unreactOptions, cmd = m.unreactOptions.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Remove the chosen reaction
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			i, ok := m.unreactOptions.SelectedItem().(QuickAction)
+			if selected, found := m.selectedMsg(); ok && found {
+				m.currentPage = pageMessages
+				cmds = append(cmds, func() tea.Msg {
+					return m.removeReaction(i.id, selected)
+				})
+			}
+		}
+
+	case pageReactors:
+		var cmd tea.Cmd
+		m.reactorOptions, cmd = m.reactorOptions.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case pageLinks:
+		var cmd tea.Cmd
+		m.linkOptions, cmd = m.linkOptions.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Follow the chosen link
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			i, ok := m.linkOptions.SelectedItem().(QuickAction)
+			if selected, found := m.selectedMsg(); ok && found {
+				links := findPermalinks(selected.Content)
+				if index, err := strconv.Atoi(i.id); err == nil && index < len(links) {
+					m.currentPage = pageMessages
+					cmds = append(cmds, m.followPermalink(links[index]))
+				}
+			}
+		}
+
+	case pageThread:
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "y" {
+			cmds = append(cmds, m.copyThread())
+			break
+		}
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && m.scrollPage(keyMsg) {
+			break
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		cmds = append(cmds, cmd)
+
+	case pageMembers:
+		var cmd tea.Cmd
+		m.memberOptions, cmd = m.memberOptions.Update(msg)
+		cmds = append(cmds, cmd)
+
+		// Filter the messages to the chosen member
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" && m.memberOptions.FilterState() != list.Filtering {
+			i, ok := m.memberOptions.SelectedItem().(QuickAction)
+			if ok {
+				m.fromUserID = i.id
+				m.fromUserName = i.name
+				m.selectedMessage = 0
+				m.refreshMessages()
+				m.currentPage = pageMessages
+			}
+		}
+
+	case pagePeople:
+		cmds = append(cmds, m.updatePeople(msg))
 
-		// Update viewport dimensions
-		m.viewport.Width = msg.Width - 4
-		m.viewport.Height = msg.Height - headerHeight - footerHeight
+	case pageMentions:
+		cmds = append(cmds, m.updateMentions(msg))
 
-		return m, nil
+	case pageRoster:
+		cmds = append(cmds, m.updateRoster(msg))
 
-	case spinner.TickMsg:
+	case pageChannelList:
+		cmds = append(cmds, m.updateChannels(msg))
+
+	case pageDownloads:
 		var cmd tea.Cmd
-		m.spinner, cmd = m.spinner.Update(msg)
+		m.downloadList, cmd = m.downloadList.Update(msg)
 		cmds = append(cmds, cmd)
 
-	case initMsg:
-		m.slackClient = msg.client
-		m.userID = msg.userID
-		m.userName = msg.userName
-		m.channels = msg.channels
-		m.isLoading = false
-
-		// After initialization, fetch messages
-		cmds = append(cmds, m.fetchMessages)
-
-	case errMsg:
-		m.error = msg.Error()
-		m.isLoading = false
-
-	case messagesMsg:
-		m.messages = msg.messages
-		m.isLoading = false
+	case pageSettings:
+		cmds = append(cmds, m.updateSettings(msg))
 
-		// Update viewport with messages
-		m.viewport.SetContent(m.formatMessages())
+	case pageCompose:
+		// Drop the quoted message from the reply
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+r" {
+			m.composeQuote = ""
+			return m, tea.Batch(cmds...)
+		}
 
-	case statusUpdatedMsg:
-		m.userStatus = msg.status
-		m.isLoading = false
-		m.currentPage = pageMain
+		// Switch between posting as the persona and as the user
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "ctrl+p" && m.composeMode != composeEdit && m.personaAvailable() {
+			m.composeAsPersona = !m.composeAsPersona
+			return m, tea.Batch(cmds...)
+		}
 
-	case messageSentMsg:
-		m.isLoading = false
-		m.currentPage = pageMain
+		// Send on the send key, otherwise keep editing; the newline key starts a new line
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == m.config.Keys.SendMessage {
+			text := strings.TrimSpace(m.composeInput.Value())
+			if text != "" {
+				m.isLoading = true
+				cmds = append(cmds, func() tea.Msg {
+					return m.submitCompose(text)
+				})
+			}
+			return m, tea.Batch(cmds...)
+		}
 
-		// Refresh messages after sending
-		cmds = append(cmds, m.fetchMessages)
-	}
+		var cmd tea.Cmd
+		m.composeInput, cmd = m.composeInput.Update(msg)
+		cmds = append(cmds, cmd)
 
-	// Handle page-specific updates
-	switch m.currentPage {
-	case pageMain:
+	case pageWorkspaces:
 		var cmd tea.Cmd
-		m.quickActions, cmd = m.quickActions.Update(msg)
+		m.workspaceList, cmd = m.workspaceList.Update(msg)
 		cmds = append(cmds, cmd)
 
-		// Handle selection on main page
-		if _, ok := msg.(tea.KeyMsg); ok {
-			switch msg := msg.(type) {
-			case tea.KeyMsg:
-				if msg.String() == "enter" {
-					i, ok := m.quickActions.SelectedItem().(QuickAction)
-					if ok {
-						switch i.name {
-						case "View Messages":
-							m.currentPage = pageMessages
-							m.isLoading = true
-							cmds = append(cmds, m.fetchMessages)
-						case "Set Status":
-							m.currentPage = pageSetStatus
-						case "Send Preset Message":
-							m.currentPage = pagePresetMessage
-						case "Quit":
-							return m, tea.Quit
-						}
-					}
+		// Switch to the chosen workspace, unless it's the one already connected
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			if i, ok := m.workspaceList.SelectedItem().(QuickAction); ok {
+				if i.id == m.workspace {
+					m.currentPage = pageMain
+				} else {
+					cmds = append(cmds, m.switchWorkspace(i.id))
 				}
 			}
 		}
 
-	case pageMessages:
-		// Handle viewport scrolling
+	case pageStatusClear:
 		var cmd tea.Cmd
-		m.viewport, cmd = m.viewport.Update(msg)
+		m.statusClearOptions, cmd = m.statusClearOptions.Update(msg)
 		cmds = append(cmds, cmd)
 
+		// Set the status to clear when chosen
+		if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" {
+			if i, ok := m.statusClearOptions.SelectedItem().(QuickAction); ok {
+				cmds = append(cmds, m.confirmStatusClear(i.id))
+			}
+		}
+
 	case pageSetStatus:
 		var cmd tea.Cmd
 		m.statusOptions, cmd = m.statusOptions.Update(msg)
@@ -602,22 +3086,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case tea.KeyMsg:
 				if msg.String() == "enter" {
 					i, ok := m.statusOptions.SelectedItem().(QuickAction)
-					if ok {
+					if ok && i.id == statusClearID {
 						m.isLoading = true
-						switch i.name {
-						case "Active":
-							cmds = append(cmds, func() tea.Msg {
-								return m.setStatus(statusActive)
-							})
-						case "Away":
-							cmds = append(cmds, func() tea.Msg {
-								return m.setStatus(statusAway)
-							})
-						case "Do Not Disturb":
-							cmds = append(cmds, func() tea.Msg {
-								return m.setStatus(statusDND)
-							})
-						}
+						cmds = append(cmds, m.clearStatus)
+					} else if index, err := strconv.Atoi(i.id); ok && err == nil && index < len(m.config.Statuses) {
+						m.openStatusClear(m.config.Statuses[index])
 					}
 				}
 			}
@@ -648,23 +3121,389 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// The channel the user is currently reading, if any
+func (m Model) focusedChannel() string {
+	if m.currentPage != pageMessages {
+		return ""
+	}
+	if m.selectedChannelID != "" {
+		return m.selectedChannelID
+	}
+	if selected, ok := m.selectedMsg(); ok {
+		return selected.ChannelID
+	}
+	return ""
+}
+
+// Track changes of the focused channel, starting the mark-read delay when a new one gains focus
+func (m *Model) updateFocus() tea.Cmd {
+	channelID := m.focusedChannel()
+	if channelID == m.focusedChannelID {
+		return nil
+	}
+
+	m.focusedChannelID = channelID
+	m.focusSeq++
+	if channelID == "" || !m.config.MarkReadOnView {
+		return nil
+	}
+	return m.markReadTick()
+}
+
+// Wait out the mark-read delay for the focused channel
+func (m Model) markReadTick() tea.Cmd {
+	seq := m.focusSeq
+	channelID := m.focusedChannelID
+	delay := time.Duration(m.config.MarkReadDelaySeconds) * time.Second
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return markReadTickMsg{seq: seq, channelID: channelID}
+	})
+}
+
+// Timestamp of the newest loaded message in a channel
+func (m Model) latestTimestamp(channelID string) string {
+	latest := ""
+	for _, msg := range m.messages {
+		if msg.ChannelID == channelID && msg.Timestamp > latest {
+			latest = msg.Timestamp
+		}
+	}
+	return latest
+}
+
+// When the channel last had a message, e.g. "#general: last activity 2 minutes ago (14:03)",
+// or "" if none of its messages are loaded
+func (m Model) lastActivity(channelID string) string {
+	ts := m.latestTimestamp(channelID)
+	if channelID == "" || ts == "" {
+		return ""
+	}
+
+	now := time.Now().In(m.configuredLocation())
+	latest := parseSlackTimestamp(ts).In(now.Location())
+	if latest.IsZero() {
+		return ""
+	}
+	when := latest.Format("15:04")
+	if !sameDay(latest, now) {
+		when = latest.Format("Jan 2 15:04")
+	}
+	return fmt.Sprintf(tr("messages.last_activity"), m.channelName(channelID), timeAgo(latest, now), when)
+}
+
+// Move the channel's read marker up to the given message
+func (m *Model) markChannelRead(channelID, ts string) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	err := m.slackClient.MarkConversation(channelID, ts)
+	return channelMarkedMsg{channelID: channelID, err: err}
+}
+
+// Mark a channel as read on request, saying so when none of its messages are loaded
+func (m *Model) markFocusedRead(channelID string) tea.Cmd {
+	ts := m.latestTimestamp(channelID)
+	if ts == "" {
+		return m.showToast(tr("toast.nothing_to_mark"), false)
+	}
+	return func() tea.Msg {
+		msg := m.markChannelRead(channelID, ts)
+		if marked, ok := msg.(channelMarkedMsg); ok {
+			marked.manual = true
+			return marked
+		}
+		return msg
+	}
+}
+
 // Format messages for display
 func (m Model) formatMessages() string {
 	var sb strings.Builder
 
-	if len(m.messages) == 0 {
-		sb.WriteString("No messages found.")
+	visible := m.visibleMessages()
+	if len(visible) == 0 {
+		sb.WriteString(tr("messages.empty"))
 		return sb.String()
 	}
 
-	for _, msg := range m.messages {
-		sb.WriteString(fmt.Sprintf(
-			"%s %s in #%s\n%s\n\n",
-			channelStyle.Render(msg.Time.Format("15:04")),
-			titleStyle.Render(msg.User),
-			channelStyle.Render(msg.Channel),
-			messageStyle.Render(msg.Content),
-		))
+	dividers := m.catchUpDividers(visible)
+	for i := range visible {
+		sb.WriteString(m.formatMessage(i, visible, dividers))
+	}
+
+	return sb.String()
+}
+
+// Snapshot the last-seen markers when opening the messages view, so the divider stays put while reading
+func (m Model) catchUpStart() map[string]string {
+	markers := make(map[string]string, len(m.state.LastSeen))
+	for channelID, ts := range m.state.LastSeen {
+		markers[channelID] = ts
+	}
+
+	// Channels never seen before get the configured window instead
+	if m.config.CatchUpWindowHours > 0 {
+		since := time.Now().Add(-time.Duration(m.config.CatchUpWindowHours) * time.Hour)
+		window := fmt.Sprintf("%d.000000", since.Unix())
+		for _, ch := range m.channels {
+			if _, ok := markers[ch.ID]; !ok {
+				markers[ch.ID] = window
+			}
+		}
+	}
+
+	return markers
+}
+
+// Indexes of the messages the catch-up divider goes next to: in each channel,
+// the oldest one newer than its marker when an older one is loaded too, or
+// when it's the channel's first loaded message
+func (m Model) catchUpDividers(visible []SlackMessage) map[int]bool {
+	dividers := make(map[int]bool)
+	// Whether each channel's previous, older message had been seen
+	olderSeen := make(map[string]bool)
+
+	// Walk from the oldest message, which is last in newest-first order
+	for n := range visible {
+		i := n
+		if m.newestFirst {
+			i = len(visible) - 1 - n
+		}
+		msg := visible[i]
+		marker, ok := m.catchUpMarkers[msg.ChannelID]
+		if !ok {
+			continue
+		}
+		if msg.Timestamp <= marker {
+			olderSeen[msg.ChannelID] = true
+			continue
+		}
+		if seen, found := olderSeen[msg.ChannelID]; !found || seen {
+			dividers[i] = true
+		}
+		olderSeen[msg.ChannelID] = false
+	}
+	return dividers
+}
+
+// Format a single message of those visible, marking it if it's the selected one
+// and adding the catch-up divider found by catchUpDividers
+func (m Model) formatMessage(index int, visible []SlackMessage, dividers map[int]bool) string {
+	rendered := m.renderMessage(visible[index], index == m.selectedMessage)
+
+	// The divider goes between the new messages and the ones seen before
+	if dividers[index] {
+		if m.newestFirst {
+			rendered += infoStyle.Render(tr("messages.since_last_seen_above")) + "\n\n"
+		} else {
+			rendered = infoStyle.Render(tr("messages.since_last_seen")) + "\n\n" + rendered
+		}
+	}
+
+	// The oldest message carries the edge of the loaded history: on top in oldest-first order, below in newest-first
+	if edge := m.historyEdge(); edge != "" {
+		if !m.newestFirst && index == 0 {
+			rendered = edge + "\n\n" + rendered
+		} else if m.newestFirst && index == len(visible)-1 {
+			rendered += edge + "\n\n"
+		}
+	}
+
+	// The channel's pinned messages stay on top, whichever order the messages are in
+	if pinned := m.pinnedPreview(); pinned != "" && index == 0 {
+		rendered = pinned + "\n\n" + rendered
+	}
+
+	return rendered
+}
+
+// Render a message's header, text, and files, with a marker when it's highlighted
+func (m Model) renderMessage(msg SlackMessage, selected bool) string {
+	marker := "  "
+	if selected {
+		marker = selectedMarkerStyle.Render("▌ ")
+	}
+
+	details := ""
+	if selected && m.detailKey == msg.ChannelID+msg.Timestamp {
+		details = m.formatDetails(msg)
+	}
+
+	// Wrap the text at word boundaries to the viewport, keeping Slack's own line breaks
+	text := messageStyle
+	if width := m.messageWidth(); width > text.GetHorizontalPadding() {
+		text = text.Width(width)
+	}
+
+	// Don't pass off a timestamp that couldn't be read as midnight
+	sent := msg.Time.Format("15:04")
+	if msg.Time.IsZero() {
+		sent = "--:--"
+	}
+
+	return fmt.Sprintf(
+		"%s%s %s %s %s%s\n%s%s\n%s%s\n",
+		marker,
+		channelStyle.Render(sent),
+		titleStyle.Render(msg.User),
+		tr("messages.in_channel"),
+		m.channelLabelStyle(msg.ChannelID).Render(m.channelLabel(msg.Channel)),
+		m.formatBadges(msg, selected),
+		details,
+		text.Render(m.highlightSearch(m.renderMrkdwn(msg.Content))),
+		m.formatFiles(msg),
+		m.formatReactions(msg),
+	)
+}
+
+// Format a message's full metadata, one line per field
+func (m Model) formatDetails(msg SlackMessage) string {
+	loc := m.configuredLocation()
+	lines := []string{fmt.Sprintf(tr("details.sent"), msg.Time.In(loc).Format("2006-01-02 15:04:05 MST"))}
+
+	if msg.Edited != nil {
+		editor := msg.Edited.User
+		if editor == "" || editor == msg.UserID {
+			editor = msg.User
+		} else if name, ok := m.userNames.get(editor); ok {
+			editor = name
+		}
+		lines = append(lines, fmt.Sprintf(tr("details.edited"),
+			parseSlackTimestamp(msg.Edited.Timestamp).In(loc).Format("2006-01-02 15:04:05 MST"), editor))
+	}
+
+	ids := fmt.Sprintf(tr("details.ts"), msg.Timestamp, msg.ChannelID, msg.UserID)
+	if msg.isThreadReply() {
+		ids += " " + fmt.Sprintf(tr("details.thread_ts"), msg.ThreadTS)
+	}
+	lines = append(lines, ids)
+
+	if link := m.messagePermalink(msg); link != "" {
+		lines = append(lines, fmt.Sprintf(tr("details.link"), link))
+	}
+
+	return messageStyle.Render(infoStyle.Render(strings.Join(lines, "\n"))) + "\n"
+}
+
+// Style for a channel's name, accented if it's the selected channel
+func (m Model) channelLabelStyle(channelID string) lipgloss.Style {
+	if channelID != "" && channelID == m.selectedChannelID {
+		return selectedChannelStyle
+	}
+	return channelStyle
+}
+
+// Format a message's reactions as one line of chips, highlighting the ones the user added
+func (m Model) formatReactions(msg SlackMessage) string {
+	if len(msg.Reactions) == 0 {
+		return ""
+	}
+
+	own := make(map[string]bool)
+	for _, name := range m.ownReactions(msg) {
+		own[name] = true
+	}
+
+	chips := make([]string, len(msg.Reactions))
+	for i, reaction := range msg.Reactions {
+		chip := fmt.Sprintf("%s %d", m.renderEmoji(":"+reaction.Name+":"), reaction.Count)
+		if own[reaction.Name] {
+			chips[i] = selectedMarkerStyle.Render("[" + chip + "]")
+		} else {
+			chips[i] = infoStyle.Render(" " + chip + " ")
+		}
+	}
+	return messageStyle.Render(strings.Join(chips, " ")) + "\n"
+}
+
+// Format the edited and saved badges that trail a message's header line
+func (m Model) formatBadges(msg SlackMessage, selected bool) string {
+	var badges []string
+
+	if msg.IsStarred {
+		badges = append(badges, "🔖")
+	}
+
+	if msg.Edited != nil {
+		badge := tr("messages.edited")
+
+		// Spell out when and by whom for the selected message
+		if selected {
+			editor := msg.Edited.User
+			if editor == "" || editor == msg.UserID {
+				editor = msg.User
+			}
+			badge = fmt.Sprintf(tr("messages.edited_detail"),
+				parseSlackTimestamp(msg.Edited.Timestamp).Format("2006-01-02 15:04"), editor)
+		}
+		badges = append(badges, infoStyle.Render(badge))
+	}
+
+	// Thread parents say how many replies are under them
+	if msg.ReplyCount > 0 && m.currentPage != pageThread {
+		badges = append(badges, infoStyle.Render("💬 "+plural(msg.ReplyCount, "messages.reply", "messages.replies")))
+	}
+
+	// Replies seen outside their thread get a pointer back to the parent
+	if msg.isThreadReply() && m.currentPage != pageThread {
+		badge := tr("messages.thread_reply")
+		if parent := m.threadParentAuthor(msg); parent != "" {
+			badge = fmt.Sprintf(tr("messages.thread_reply_to"), parent)
+		}
+		badges = append(badges, infoStyle.Render(badge))
+	}
+
+	if len(badges) == 0 {
+		return ""
+	}
+	return " " + strings.Join(badges, " ")
+}
+
+// Author of a reply's parent message, if the parent is loaded
+func (m Model) threadParentAuthor(msg SlackMessage) string {
+	for _, candidate := range m.messages {
+		if candidate.ChannelID == msg.ChannelID && candidate.Timestamp == msg.ThreadTS {
+			return candidate.User
+		}
+	}
+	return ""
+}
+
+// Format the files attached to a message, one metadata line each
+func (m Model) formatFiles(msg SlackMessage) string {
+	var sb strings.Builder
+
+	for _, f := range msg.Files {
+		if restriction := fileRestriction(f); restriction != "" {
+			sb.WriteString(messageStyle.Render(infoStyle.Render("🔒 "+restriction)) + "\n")
+			continue
+		}
+
+		details := []string{}
+		if f.Mimetype != "" {
+			details = append(details, f.Mimetype)
+		}
+		details = append(details, formatFileSize(f.Size))
+		if f.OriginalW > 0 && f.OriginalH > 0 {
+			details = append(details, fmt.Sprintf("%dx%d", f.OriginalW, f.OriginalH))
+		}
+
+		// The author normally shared the file; otherwise fall back to the sharer's ID
+		sharer := msg.User
+		if f.User != "" && f.User != msg.UserID {
+			sharer = f.User
+		}
+		details = append(details, fmt.Sprintf(tr("files.shared_by"), sharer))
+		if f.Created != 0 {
+			details = append(details, f.Created.Time().Format("2006-01-02 15:04"))
+		}
+
+		sb.WriteString(messageStyle.Render(fmt.Sprintf(
+			"📎 %s %s",
+			fileName(f),
+			infoStyle.Render("("+strings.Join(details, ", ")+")"),
+		)) + "\n")
 	}
 
 	return sb.String()
@@ -673,7 +3512,10 @@ func (m Model) formatMessages() string {
 // Render the view based on current state
 func (m Model) View() string {
 	if m.width == 0 {
-		return "Initializing..."
+		return tr("app.initializing")
+	}
+	if m.tooSmall() {
+		return fmt.Sprintf(tr("app.too_small"), minTerminalWidth, minTerminalHeight)
 	}
 
 	var content string
@@ -681,60 +3523,333 @@ func (m Model) View() string {
 	// Header displays user info and status
 	header := fmt.Sprintf(
 		"%s | %s",
-		titleStyle.Render(fmt.Sprintf("Slack TUI - Logged in as: %s", m.userName)),
+		titleStyle.Render(fmt.Sprintf(tr("app.header"), m.userName)),
 		func() string {
+			if m.autoAway {
+				return statusAwayStyle.Render("● " + tr("status.idle_away"))
+			}
 			switch m.userStatus {
 			case statusActive:
-				return statusActiveStyle.Render("● Active")
+				return statusActiveStyle.Render("● " + tr("status.active"))
 			case statusAway:
-				return statusAwayStyle.Render("● Away")
+				return statusAwayStyle.Render("● " + tr("status.away"))
 			case statusDND:
-				return statusDNDStyle.Render("● Do Not Disturb")
+				return statusDNDStyle.Render("● " + tr("status.dnd"))
+			case statusCleared:
+				return infoStyle.Render("○ " + tr("status.cleared"))
 			default:
-				return infoStyle.Render("● Unknown")
+				return infoStyle.Render("● " + tr("status.unknown"))
 			}
 		}(),
 	)
 
-	// Footer with help text
-	footer := helpStyle.Render("q/ctrl+c: quit • esc: back • ↑/↓: navigate • enter: select")
+	// With several workspaces, name the one connected to
+	if len(m.config.Workspaces) > 0 && m.workspaceLabel() != "" {
+		header += " | " + infoStyle.Render(m.workspaceLabel())
+	}
+
+	// Name the selected channel so it's always clear where actions go
+	if m.selectedChannelID != "" {
+		header += " | " + selectedChannelStyle.Render(m.channelLabel(m.channelName(m.selectedChannelID)))
+	}
+
+	// Say when new messages stop arriving live until the connection comes back
+	if m.rtm != nil && m.liveState == liveReconnecting {
+		header += " | " + statusAwayStyle.Render("○ "+tr("app.reconnecting"))
+	}
+
+	// A quiet hint that Slack has flagged something, with the details in the debug log
+	if m.config.ShowAPIWarnings {
+		if count := m.apiWarnings.count(); count > 0 {
+			header += " | " + helpStyle.Render(fmt.Sprintf(tr("app.api_warnings"), count))
+		}
+	}
+
+	// Footer with help text for the keys of the page on screen
+	footer := helpStyle.Render(m.footerHelp(m.currentPage))
+
+	// Show any transient notification above the footer
+	if m.toast != "" {
+		toast := toastStyle.Render(m.toast)
+		if m.toastIsError {
+			toast = errorStyle.Render(m.toast)
+		}
+		footer = lipgloss.JoinVertical(lipgloss.Center, toast, footer)
+	}
 
 	// Display error if any
 	if m.error != "" {
-		errorBox := errorStyle.Render(fmt.Sprintf("Error: %s", m.error))
+		errorBox := errorStyle.Render(fmt.Sprintf(tr("app.error"), m.error))
 		content = lipgloss.JoinVertical(lipgloss.Center, header, errorBox, footer)
 		return appStyle.Render(content)
 	}
 
 	// Display loading spinner if loading
 	if m.isLoading {
-		loadingText := fmt.Sprintf("%s Loading...", m.spinner.View())
+		loadingText := fmt.Sprintf("%s %s", m.spinner.View(), tr("app.loading"))
 		content = lipgloss.JoinVertical(lipgloss.Center, header, loadingText, footer)
 		return appStyle.Render(content)
 	}
 
+	// In focus mode the messages fill the terminal, with any notification over the last line
+	if m.focused() {
+		view := m.viewport.View()
+		if m.toast != "" {
+			lines := strings.Split(view, "\n")
+			toast := toastStyle.Render(m.toast)
+			if m.toastIsError {
+				toast = errorStyle.Render(m.toast)
+			}
+			lines[len(lines)-1] = truncate(toast, m.width)
+			view = strings.Join(lines, "\n")
+		}
+		return view
+	}
+
 	// Content based on current page
 	switch m.currentPage {
 	case pageMain:
-		content = lipgloss.JoinVertical(lipgloss.Center, header, m.quickActions.View(), footer)
+		if m.confirmingQuit {
+			content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(tr("quit.confirm")), footer)
+			break
+		}
+		if summary := m.scheduleSummary(); summary != "" {
+			content = lipgloss.JoinVertical(lipgloss.Center, header, m.quickActions.View(), infoStyle.Render(summary), footer)
+		} else {
+			content = lipgloss.JoinVertical(lipgloss.Center, header, m.quickActions.View(), footer)
+		}
 	case pageMessages:
-		content = lipgloss.JoinVertical(lipgloss.Center, header, m.viewport.View(), footer)
+		status := tr("messages.oldest_first")
+		if m.newestFirst {
+			status = tr("messages.newest_first")
+		}
+		if m.fromUserID != "" {
+			status = fmt.Sprintf(tr("messages.from_user"), m.fromUserName) + " • " + status
+		}
+		if activity := m.lastActivity(m.focusedChannel()); activity != "" {
+			status = activity + " • " + status
+		}
+		if m.selectedChannelID == "" {
+			// Leave the rest of the line room when saying which channels are shown
+			summary := m.aggregateSummary(m.width - 10 - lipgloss.Width(status) - 3)
+			if summary != "" {
+				status = summary + " • " + status
+			}
+		}
+		if progress := m.downloadProgress(); progress != "" {
+			status += " • " + progress
+		}
+		if muted := m.mutedCount(); muted > 0 {
+			if m.showMuted {
+				status += " • " + fmt.Sprintf(tr("messages.muted_shown"), muted)
+			} else {
+				status += " • " + fmt.Sprintf(tr("messages.muted_hidden"), muted)
+			}
+		}
+		if m.searching || m.searchQuery != "" {
+			status = m.searchStatus()
+		}
+		messages := m.viewport.View()
+		if m.splitActive() {
+			messages = lipgloss.JoinHorizontal(lipgloss.Top, m.channelPane(), messages)
+		}
+		content = lipgloss.JoinVertical(lipgloss.Center, header, infoStyle.Render(status), messages, footer)
 	case pageSetStatus:
 		content = lipgloss.JoinVertical(lipgloss.Center, header, m.statusOptions.View(), footer)
+	case pageWorkspaces:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.workspaceList.View(), footer)
+	case pageStatusClear:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.statusClearOptions.View()), footer)
 	case pagePresetMessage:
 		content = lipgloss.JoinVertical(lipgloss.Center, header, m.presetMessages.View(), footer)
+	case pagePeople:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.people.View(), footer)
+	case pageMentions:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.mentions.View(), footer)
+	case pageRoster:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.roster.View(), footer)
+	case pageChannelList:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.textInput.View(), m.channelList.View(), helpStyle.Render(tr("channels.help")), footer)
+	case pageDownloads:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.downloadList.View(), footer)
+	case pageSettings:
+		parts := []string{header, m.settingsList.View()}
+		if m.editingSetting != "" {
+			parts = append(parts, menuStyle.Render(lipgloss.JoinHorizontal(lipgloss.Center, titleStyle.Render(m.editingSetting), m.settingInput.View())))
+		}
+		if m.settingError != "" {
+			parts = append(parts, errorStyle.Render(m.settingError))
+		}
+		parts = append(parts, helpStyle.Render(tr("settings.help")), footer)
+		content = lipgloss.JoinVertical(lipgloss.Center, parts...)
+	case pageMessageMenu:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.messageActions.View()), footer)
+	case pageReactions:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.reactionOptions.View()), footer)
+	case pageCodeBlocks:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.codeBlocks.View()), footer)
+	case pageFiles:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.fileOptions.View()), footer)
+	case pageMembers:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.memberOptions.View()), footer)
+	case pageLinks:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.linkOptions.View()), footer)
+	case pageUnreact:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.unreactOptions.View()), footer)
+	case pageReactors:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.reactorOptions.View()), footer)
+	case pageChannelInfo:
+		content = lipgloss.JoinVertical(lipgloss.Center, header, m.menuOverlay(m.channelInfoView()), footer)
+	case pageThread:
+		title := infoStyle.Render(fmt.Sprintf(tr("thread.title"), m.channelName(m.threadLink.channelID)) + " • " + tr("thread.help"))
+		content = lipgloss.JoinVertical(lipgloss.Center, header, title, m.viewport.View(), footer)
+	case pageCompose:
+		title := fmt.Sprintf(tr("compose.reply"), m.composeMessage.User)
+		switch m.composeMode {
+		case composeChannel:
+			title = fmt.Sprintf(tr("compose.reply_channel"), m.composeMessage.Channel)
+		case composeEdit:
+			title = tr("compose.edit")
+		case composeNew:
+			title = fmt.Sprintf(tr("compose.new"), m.composeMessage.Channel)
+			if m.composeMessage.ChannelID == "" {
+				title = tr("compose.new_any")
+			}
+		}
+		parts := []string{titleStyle.Render(title)}
+		if m.composeQuote != "" {
+			parts = append(parts, infoStyle.Render(strings.TrimSuffix(quoteText(m.composeQuote), "\n")), helpStyle.Render(tr("compose.drop_quote")))
+		}
+		if m.composeMode != composeEdit && m.personaAvailable() {
+			persona := strings.TrimSpace(m.config.Persona.IconEmoji + " " + m.config.Persona.Username)
+			if m.composeAsPersona {
+				parts = append(parts, helpStyle.Render(fmt.Sprintf(tr("compose.as_persona"), persona)))
+			} else {
+				parts = append(parts, helpStyle.Render(fmt.Sprintf(tr("compose.as_self"), persona)))
+			}
+		}
+		parts = append(parts, m.composeInput.View(), helpStyle.Render(fmt.Sprintf(tr("compose.keys"), m.config.Keys.SendMessage, m.config.Keys.NewLine)))
+		compose := lipgloss.JoinVertical(lipgloss.Left, parts...)
+		content = lipgloss.JoinVertical(lipgloss.Center, header, menuStyle.Render(compose), footer)
 	}
 
 	return appStyle.Render(content)
 }
 
+// The footer's help text for a page, naming the keys that matter there
+func (m Model) footerHelp(page string) string {
+	switch page {
+	case pageMain:
+		return tr("app.footer")
+	case pageMessages:
+		return tr("messages.footer")
+	case pageThread:
+		return tr("footer.thread")
+	case pageCompose:
+		return fmt.Sprintf(tr("footer.compose"), m.config.Keys.SendMessage)
+	case pageChannelList:
+		return tr("footer.channels")
+	case pageSetStatus, pageStatusClear:
+		return tr("footer.status")
+	case pageChannelInfo:
+		return tr("footer.back")
+	default:
+		return tr("footer.list")
+	}
+}
+
+// Quit, or ask first with confirmQuit on
+func (m *Model) requestQuit() tea.Cmd {
+	if m.config.ConfirmQuit {
+		m.confirmingQuit = true
+		return nil
+	}
+	return tea.Quit
+}
+
+// Center a menu over the area normally taken by the message viewport
+func (m Model) menuOverlay(menu string) string {
+	width, height := m.chromeContentSize()
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		menuStyle.Render(menu),
+	)
+}
+
 func main() {
+	doctor := flag.Bool("doctor", false, "check the Slack token, scopes, and config, then exit")
+	compose := flag.String("compose", "", "start by composing a message to this channel, skipping the menu")
+	once := flag.Bool("once", false, "with --compose, quit after sending the message")
+	noFetch := flag.Bool("no-fetch", false, "start at the menu without fetching messages until a view is opened")
+	theme := flag.String("theme", "", "pick the colors for a light or dark terminal background, overriding theme.mode")
+	flag.Parse()
+
+	// Run the setup checks instead of the TUI
+	if *doctor {
+		os.Exit(runDoctor())
+	}
+
+	// Load the config, falling back to the defaults if it can't be read
+	cfg, cfgErr := loadConfig()
+	if cfgErr != nil {
+		log.Printf("Error loading config: %v", cfgErr)
+	}
+
+	// Flags take precedence over the config
+	flags := flagOverrides{compose: *compose, once: *once, noFetch: *noFetch, theme: *theme}
+	flags.apply(&cfg)
+
+	// Load what was remembered from the last session
+	state, err := loadState()
+	if err != nil {
+		log.Printf("Error loading state: %v", err)
+	}
+
+	// Log while the TUI runs only to the debug log, if there is one, since anything
+	// written to the terminal would tear through the screen
+	if cfg.DebugLog != "" {
+		f, err := tea.LogToFile(cfg.DebugLog, "")
+		if err != nil {
+			log.Printf("Error opening debug log: %v", err)
+			log.SetOutput(io.Discard)
+		} else {
+			defer f.Close()
+		}
+	} else {
+		log.SetOutput(io.Discard)
+	}
+
 	// Initialize the model
-	m := initialModel()
+	m := initialModel(cfg, state)
+	m.flags = flags
+
+	// The log is gone once the TUI starts, so a config file that couldn't be read is reported there too
+	if cfgErr != nil {
+		m.configWarnings = append([]string{fmt.Sprintf(tr("config.load_failed"), cfgErr)}, m.configWarnings...)
+	}
 
 	// Start the program
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if cfg.Mouse {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(m, options...)
+	final, err := p.Run()
+	if cfg.DebugLog == "" {
+		log.SetOutput(os.Stderr)
+	}
+	if err != nil {
 		log.Fatalf("Error running program: %v", err)
 	}
+
+	// Save what should carry over to the next session
+	if final, ok := final.(Model); ok {
+		final.state.LastPage = final.resumablePage()
+		if err := saveState(final.state); err != nil {
+			log.Printf("Error saving state: %v", err)
+		}
+	}
 }
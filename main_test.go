@@ -0,0 +1,428 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+func TestSetStatusCustomOption(t *testing.T) {
+	tests := []struct {
+		status StatusOption
+		want   []string
+	}{
+		{
+			status: StatusOption{Name: "Focus", Presence: statusActive, StatusText: "Heads down", Emoji: ":headphones:"},
+			want:   []string{`SetUserPresence("auto")`, `SetUserCustomStatus("Heads down", ":headphones:", 0)`},
+		},
+		{
+			status: StatusOption{Name: "Gym", Presence: statusAway, StatusText: "Working out", Emoji: ":muscle:"},
+			want:   []string{`SetUserPresence("away")`, `SetUserCustomStatus("Working out", ":muscle:", 0)`},
+		},
+		{
+			// DND has no presence of its own, so only the custom status changes
+			status: StatusOption{Name: "Deep work", Presence: statusDND, StatusText: "No meetings", Emoji: ":no_entry:"},
+			want:   []string{`SetUserCustomStatus("No meetings", ":no_entry:", 0)`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.Name, func(t *testing.T) {
+			client := &fakeSlack{}
+			m := newTestModel(client)
+
+			msg, ok := m.setStatus(tt.status, 0).(statusUpdatedMsg)
+			if !ok {
+				t.Fatalf("setStatus didn't report an update")
+			}
+			if msg.status != tt.status.Presence || msg.label != tt.status.Name {
+				t.Errorf("statusUpdatedMsg = %+v", msg)
+			}
+			equalCalls(t, client.called(), tt.want)
+		})
+	}
+}
+
+func TestValidPresence(t *testing.T) {
+	for _, p := range []string{statusActive, statusAway, statusDND} {
+		if !validPresence(p) {
+			t.Errorf("validPresence(%q) = false, want true", p)
+		}
+	}
+	for _, p := range []string{"", "auto", "busy", "Active"} {
+		if validPresence(p) {
+			t.Errorf("validPresence(%q) = true, want false", p)
+		}
+	}
+}
+
+func TestNextCycleStatusSkipsUnknown(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.config.StatusCycle = []string{statusActive, "busy", statusDND}
+
+	m.userStatus = statusActive
+	if got := m.nextCycleStatus(); got != statusDND {
+		t.Errorf("nextCycleStatus() = %q, want %q", got, statusDND)
+	}
+}
+
+func TestRefreshKeepsChannel(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C2"
+
+	updated, cmd := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(Model)
+	if !m.isLoading {
+		t.Error("r should show the spinner while the messages load")
+	}
+	if m.selectedChannelID != "C2" {
+		t.Errorf("selectedChannelID = %q, want C2", m.selectedChannelID)
+	}
+	if !fetchesChannel(cmd, "C2") {
+		t.Error("r should fetch the messages of the same channel again")
+	}
+}
+
+// Whether running a command fetches a channel's messages
+func fetchesChannel(cmd tea.Cmd, channelID string) bool {
+	for _, msg := range runCmd(cmd) {
+		if msg, ok := msg.(messagesMsg); ok && msg.channelID == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStaleMessagesDropped(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C3"
+	m.messages = []SlackMessage{{ChannelID: "C3", Content: "dev", Timestamp: "1700000001.000000"}}
+
+	// The refresh of #random lands after switching to #dev
+	updated, _ := m.handleMsg(messagesMsg{channelID: "C2", messages: []SlackMessage{{ChannelID: "C2", Content: "random"}}})
+	m = updated.(Model)
+	if len(m.messages) != 1 || m.messages[0].Content != "dev" {
+		t.Errorf("messages = %+v, want #dev's kept", m.messages)
+	}
+}
+
+func TestComposeWithoutSelectedChannel(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+
+	m.openComposeMessage()
+	if m.currentPage != pageCompose || m.composeMessage.ChannelID != "" {
+		t.Fatalf("page = %q, target = %q; want compose with no target", m.currentPage, m.composeMessage.ChannelID)
+	}
+
+	msg, ok := m.submitCompose("#random hello").(actionResultMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("submitCompose = %+v, want a sent message", msg)
+	}
+	if msg.channelID != "C2" {
+		t.Errorf("channelID = %q, want C2", msg.channelID)
+	}
+	equalCalls(t, client.called(), []string{`PostMessage("C2", "hello")`})
+}
+
+func TestComposeUnknownChannel(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.openComposeMessage()
+
+	for _, text := range []string{"#nowhere hello", "hello"} {
+		msg, ok := m.submitCompose(text).(actionResultMsg)
+		if !ok || msg.err == nil {
+			t.Errorf("submitCompose(%q) = %+v, want an error", text, msg)
+		}
+	}
+	if msg := m.submitCompose("#nowhere hello").(actionResultMsg); msg.err.Error() != fmt.Sprintf(tr("compose.unknown_channel"), "#nowhere") {
+		t.Errorf("error = %q, want the unknown channel one", msg.err)
+	}
+	equalCalls(t, client.called(), nil)
+
+	// The text stays in compose to correct
+	updated, _ := m.handleMsg(m.submitCompose("#nowhere hello"))
+	if page := updated.(Model).currentPage; page != pageCompose {
+		t.Errorf("page = %q after a failed send, want compose", page)
+	}
+}
+
+func TestPagerArgs(t *testing.T) {
+	tests := []struct {
+		pager, editor string
+		want          []string
+	}{
+		{"less -R", "vim", []string{"less", "-R"}},
+		{"", "vim", []string{"vim"}},
+		{" ", "nano -v", []string{"nano", "-v"}},
+		{" \t", "  ", []string{"less"}},
+		{"", "", []string{"less"}},
+	}
+	for _, tt := range tests {
+		t.Setenv("PAGER", tt.pager)
+		t.Setenv("EDITOR", tt.editor)
+		if got := pagerArgs(); !slices.Equal(got, tt.want) {
+			t.Errorf("pagerArgs() with PAGER=%q EDITOR=%q = %q, want %q", tt.pager, tt.editor, got, tt.want)
+		}
+	}
+}
+
+func TestVimKeysMoveMainList(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+
+	updated, _ := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	if got := m.quickActions.Index(); got != 1 {
+		t.Fatalf("after j, selected %d, want 1", got)
+	}
+
+	updated, _ = m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m = updated.(Model)
+	if got := m.quickActions.Index(); got != 0 {
+		t.Errorf("after k, selected %d, want 0", got)
+	}
+}
+
+func TestVimKeysTypedIntoFilter(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+
+	for _, key := range []string{"/", "j", "q"} {
+		updated, cmd := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+		if quits(cmd) {
+			t.Fatalf("%q quit while typing the filter", key)
+		}
+	}
+	if got := m.quickActions.FilterValue(); got != "jq" {
+		t.Errorf("filter = %q, want jq", got)
+	}
+	if got := m.quickActions.Index(); got != 0 {
+		t.Errorf("selected %d while filtering, want 0", got)
+	}
+}
+
+func TestLoadingUntilInit(t *testing.T) {
+	m := initialModel(defaultConfig(), State{LastSeen: make(map[string]string), Drafts: make(map[string]string), LastChannels: make(map[string]string)})
+	if m.Init() == nil || !m.isLoading {
+		t.Fatal("the model should be loading while Init connects")
+	}
+
+	// Other messages before the connection don't end the loading state
+	updated, _ := m.handleMsg(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	if !m.isLoading {
+		t.Fatal("a resize ended the loading state before initMsg")
+	}
+
+	updated, _ = m.handleMsg(initMsg{workspace: m.workspace, client: &fakeSlack{}, channels: []slack.Channel{testChannel("C1", "general")}})
+	m = updated.(Model)
+	if m.isLoading {
+		t.Error("still loading after initMsg")
+	}
+}
+
+// Whether running a command quits the program
+func quits(cmd tea.Cmd) bool {
+	for _, msg := range runCmd(cmd) {
+		if _, ok := msg.(tea.QuitMsg); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConfirmQuit(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.config.ConfirmQuit = true
+
+	for _, key := range []string{"q", "x", "n", "q"} {
+		updated, cmd := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(Model)
+		if quits(cmd) {
+			t.Fatalf("%q quit before the prompt was answered with y", key)
+		}
+	}
+	if !m.confirmingQuit {
+		t.Fatal("q didn't ask again after n")
+	}
+
+	_, cmd := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if !quits(cmd) {
+		t.Error("y didn't quit")
+	}
+}
+
+func TestPickReaction(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C2"
+	m.messages = []SlackMessage{{ChannelID: "C2", Content: "shipped", Timestamp: "1700000001.000000"}}
+	m.refreshMessages()
+
+	var cmd tea.Cmd
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("+")},
+		{Type: tea.KeyRunes, Runes: []rune("j")},
+		{Type: tea.KeyEnter},
+	} {
+		var updated tea.Model
+		updated, cmd = m.handleMsg(key)
+		m = updated.(Model)
+	}
+	runCmd(cmd)
+
+	second := m.reactionOptions.Items()[1].(ReactionOption)
+	equalCalls(t, client.called(), []string{fmt.Sprintf(`AddReaction(%q, "C2", "1700000001.000000")`, second.name)})
+}
+
+func TestFooterHelp(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	compose := fmt.Sprintf(tr("footer.compose"), m.config.Keys.SendMessage)
+
+	tests := map[string]string{
+		pageMain:          tr("app.footer"),
+		pageMessages:      tr("messages.footer"),
+		pageThread:        tr("footer.thread"),
+		pageCompose:       compose,
+		pageChannelList:   tr("footer.channels"),
+		pageSetStatus:     tr("footer.status"),
+		pageStatusClear:   tr("footer.status"),
+		pageChannelInfo:   tr("footer.back"),
+		pageQuickActions:  tr("footer.list"),
+		pagePresetMessage: tr("footer.list"),
+		pageMessageMenu:   tr("footer.list"),
+		pageReactions:     tr("footer.list"),
+		pagePeople:        tr("footer.list"),
+		pageCodeBlocks:    tr("footer.list"),
+		pageFiles:         tr("footer.list"),
+		pageMembers:       tr("footer.list"),
+		pageMentions:      tr("footer.list"),
+		pageRoster:        tr("footer.list"),
+		pageLinks:         tr("footer.list"),
+		pageSettings:      tr("footer.list"),
+		pageUnreact:       tr("footer.list"),
+		pageReactors:      tr("footer.list"),
+		pageWorkspaces:    tr("footer.list"),
+		pageDownloads:     tr("footer.list"),
+	}
+	for page, want := range tests {
+		if got := m.footerHelp(page); got != want {
+			t.Errorf("footerHelp(%q) = %q, want %q", page, got, want)
+		}
+	}
+}
+
+func TestFormatFiles(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	file := slack.File{Name: "report.pdf", Mimetype: "application/pdf", Size: 2560, URLPrivate: "https://files.slack.com/report.pdf"}
+	msg := SlackMessage{User: "alice", UserID: "U1", Files: []slack.File{file}}
+
+	got := m.formatFiles(msg)
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d attachment lines, want 1:\n%s", len(lines), got)
+	}
+	if !strings.Contains(lines[0], "📎 report.pdf") || !strings.Contains(lines[0], "2 KB") {
+		t.Errorf("attachment line = %q, want report.pdf and its 2 KB size", lines[0])
+	}
+}
+
+func TestFormatFileSize(t *testing.T) {
+	tests := map[int]string{
+		512:         "512 B",
+		2048:        "2 KB",
+		5 << 20:     "5.0 MB",
+		3 << 30 / 2: "1.5 GB",
+	}
+	for size, want := range tests {
+		if got := formatFileSize(size); got != want {
+			t.Errorf("formatFileSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
+
+func TestDigitChoosesQuickAction(t *testing.T) {
+	tests := map[string]string{
+		"2": pageSetStatus,
+		"3": pageChannelList,
+	}
+	for key, want := range tests {
+		m := newTestModel(&fakeSlack{})
+		updated, _ := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if page := updated.(Model).currentPage; page != want {
+			t.Errorf("after %s, page = %q, want %q", key, page, want)
+		}
+	}
+}
+
+func TestMarkChannelRead(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C1"
+	m.messages = []SlackMessage{
+		{ChannelID: "C1", Content: "first", Timestamp: "1700000001.000000"},
+		{ChannelID: "C1", Content: "latest", Timestamp: "1700000003.000000"},
+		{ChannelID: "C1", Content: "second", Timestamp: "1700000002.000000"},
+	}
+	m.refreshMessages()
+
+	updated, cmd := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	m = updated.(Model)
+	for _, msg := range runCmd(cmd) {
+		updated, _ = m.handleMsg(msg)
+		m = updated.(Model)
+	}
+
+	equalCalls(t, client.called(), []string{`MarkConversation("C1", "1700000003.000000")`})
+	if want := fmt.Sprintf(tr("toast.marked_read"), "general"); m.toast != want {
+		t.Errorf("toast = %q, want %q", m.toast, want)
+	}
+}
+
+func TestMarkChannelReadWithoutMessages(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C1"
+	m.refreshMessages()
+
+	updated, _ := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	if toast := updated.(Model).toast; toast != tr("toast.nothing_to_mark") {
+		t.Errorf("toast = %q, want %q", toast, tr("toast.nothing_to_mark"))
+	}
+	equalCalls(t, client.called(), nil)
+}
+
+func TestCatchUpDividers(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.catchUpMarkers = map[string]string{"C1": "1700000002.000000", "C2": "1700000000.000000"}
+	// Oldest first: #general has two seen messages before its new ones, and all of #random is new
+	visible := []SlackMessage{
+		{ChannelID: "C1", Timestamp: "1700000001.000000"},
+		{ChannelID: "C2", Timestamp: "1700000001.500000"},
+		{ChannelID: "C1", Timestamp: "1700000002.000000"},
+		{ChannelID: "C1", Timestamp: "1700000003.000000"},
+		{ChannelID: "C2", Timestamp: "1700000004.000000"},
+		{ChannelID: "C3", Timestamp: "1700000005.000000"},
+		{ChannelID: "C1", Timestamp: "1700000006.000000"},
+	}
+	if got := m.catchUpDividers(visible); !maps.Equal(got, map[int]bool{1: true, 3: true}) {
+		t.Errorf("oldest first, dividers = %v, want at 1 and 3", got)
+	}
+
+	m.newestFirst = true
+	slices.Reverse(visible)
+	if got := m.catchUpDividers(visible); !maps.Equal(got, map[int]bool{5: true, 3: true}) {
+		t.Errorf("newest first, dividers = %v, want at 5 and 3", got)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rows each item takes in the full-page lists, which use the default two-line delegate
+var listItemRows = func() int {
+	d := list.NewDefaultDelegate()
+	return d.Height() + d.Spacing()
+}()
+
+// Handle a mouse event: the wheel scrolls, a click selects, and clicking what's
+// already selected acts on it like enter
+func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	switch m.currentPage {
+	case pageMessages:
+		// The channel pane in the split layout doesn't take clicks
+		if !m.focused() && msg.X < appStyle.GetBorderLeftSize()+appStyle.GetPaddingLeft()+m.channelPaneWidth() {
+			return nil
+		}
+		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+			return m.clickMessage(msg.Y)
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		m.following = m.following && m.atNewest()
+		return cmd
+
+	case pageThread:
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return cmd
+
+	case pageChannelList:
+		if msg.Action != tea.MouseActionPress {
+			return nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			m.channelList.CursorUp()
+		case tea.MouseButtonWheelDown:
+			m.channelList.CursorDown()
+		case tea.MouseButtonLeft:
+			// The filter input sits above the list
+			index, ok := m.listItemAt(m.channelList, msg.Y-1)
+			if !ok {
+				return nil
+			}
+			if index == m.channelList.Index() {
+				return m.updateChannels(tea.KeyMsg{Type: tea.KeyEnter})
+			}
+			m.channelList.Select(index)
+		}
+		return m.fetchVisibleSparklines()
+	}
+	return nil
+}
+
+// Rows above the page content: the app frame and the header line, or none in focus mode
+func (m Model) contentTop() int {
+	if m.focused() {
+		return 0
+	}
+	return appStyle.GetBorderTopSize() + appStyle.GetPaddingTop() + 1
+}
+
+// Select the message under a click on the messages page, opening its menu if it was already selected
+func (m *Model) clickMessage(y int) tea.Cmd {
+	// Below the header come the status line and the viewport's own border
+	line := y - m.contentTop() - m.viewport.Style.GetBorderTopSize() - m.viewport.Style.GetPaddingTop()
+	if !m.focused() {
+		line--
+	}
+	if line < 0 || line >= m.viewport.Height-m.viewport.Style.GetVerticalFrameSize() {
+		return nil
+	}
+	line += m.viewport.YOffset
+
+	visible := m.visibleMessages()
+	dividers := m.catchUpDividers(visible)
+	top := 0
+	for i, msg := range visible {
+		height := lipgloss.Height(m.formatMessage(i, visible, dividers))
+		if line < top+height {
+			if i == m.selectedMessage {
+				m.openMessageMenu(msg)
+				return nil
+			}
+			m.selectedMessage = i
+			m.refreshMessages()
+			return nil
+		}
+		top += height
+	}
+	return nil
+}
+
+// Index among a full-page list's visible items of the item at screen row y
+func (m Model) listItemAt(l list.Model, y int) (int, bool) {
+	row := y - m.contentTop()
+	if l.ShowTitle() {
+		row -= l.Styles.TitleBar.GetVerticalFrameSize() + 1
+	}
+	if l.ShowStatusBar() {
+		row -= l.Styles.StatusBar.GetVerticalFrameSize() + 1
+	}
+	if row < 0 {
+		return 0, false
+	}
+
+	start, end := l.Paginator.GetSliceBounds(len(l.VisibleItems()))
+	index := start + row/listItemRows
+	if index >= end {
+		return 0, false
+	}
+	return index, true
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Slack date tokens, e.g. <!date^1392734382^{date_short} at {time}|Feb 18, 2014 at 6:39 AM>,
+// optionally with a link after the format
+var dateTokenPattern = regexp.MustCompile(`<!date\^(\d+)\^([^^|>]*)(?:\^[^|>]*)?(?:\|([^>]*))?>`)
+
+// Placeholders a date token's format can contain
+var datePlaceholderPattern = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// Angle-bracket tokens in message text: mentions, links, and dates. Slack
+// escapes a literal "<" as "&lt;", so a bare one always opens a token.
+var textTokenPattern = regexp.MustCompile(`<[^<>]+>`)
+
+// Render the parts of Slack's mrkdwn that don't read well as raw text
+func (m Model) renderMrkdwn(text string) string {
+	return m.renderEmoji(m.renderMessageText(text))
+}
+
+// Rewrite the tokens in message text the way Slack shows them, e.g. <@U123> as
+// @alice, <#C123|general> as #general, and <https://…|label> as its label, and
+// unescape the rest. Tokens it doesn't recognize are left as written.
+func (m Model) renderMessageText(text string) string {
+	now := time.Now().In(m.configuredLocation())
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range textTokenPattern.FindAllStringIndex(text, -1) {
+		sb.WriteString(slackUnescaper.Replace(text[last:loc[0]]))
+		sb.WriteString(m.renderTextToken(text[loc[0]:loc[1]], now))
+		last = loc[1]
+	}
+	sb.WriteString(slackUnescaper.Replace(text[last:]))
+	return sb.String()
+}
+
+// Render a single token, including its angle brackets
+func (m Model) renderTextToken(token string, now time.Time) string {
+	if match := dateTokenPattern.FindStringSubmatch(token); match != nil && match[0] == token {
+		return renderDateToken(match, now)
+	}
+
+	target, label, hasLabel := strings.Cut(token[1:len(token)-1], "|")
+	label = slackUnescaper.Replace(label)
+
+	switch {
+	case strings.HasPrefix(target, "@") && len(target) > 1:
+		if name, ok := m.userNames.get(target[1:]); ok {
+			return "@" + name
+		}
+		if hasLabel && label != "" {
+			return "@" + strings.TrimPrefix(label, "@")
+		}
+		return target
+
+	case strings.HasPrefix(target, "#") && len(target) > 1:
+		if hasLabel && label != "" {
+			return "#" + label
+		}
+		if name := m.channelName(target[1:]); name != target[1:] {
+			return "#" + name
+		}
+		return target
+
+	case target == "!here" || target == "!channel" || target == "!everyone":
+		return "@" + target[1:]
+
+	// User groups carry their handle in the label, e.g. <!subteam^S123|@oncall>
+	case strings.HasPrefix(target, "!subteam^") && hasLabel && label != "":
+		return label
+
+	case strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:"):
+		if hasLabel && label != "" {
+			return label
+		}
+		return slackUnescaper.Replace(strings.TrimPrefix(target, "mailto:"))
+	}
+
+	return slackUnescaper.Replace(token)
+}
+
+// Render a date token from its pattern match, falling back to its text when
+// the timestamp or format can't be read
+func renderDateToken(match []string, now time.Time) string {
+	fallback := slackUnescaper.Replace(match[3])
+
+	unix, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return fallback
+	}
+	if formatted, ok := formatDateToken(match[2], time.Unix(unix, 0).In(now.Location()), now); ok {
+		return formatted
+	}
+	return fallback
+}
+
+// Fill in a date token's format the way Slack would. Returns false if the
+// format uses a placeholder Slack doesn't define.
+func formatDateToken(format string, t, now time.Time) (string, bool) {
+	ok := true
+	formatted := datePlaceholderPattern.ReplaceAllStringFunc(format, func(placeholder string) string {
+		switch placeholder {
+		case "{date_num}":
+			return t.Format("2006-01-02")
+		case "{date}":
+			return longDate(t)
+		case "{date_short}":
+			return t.Format("Jan 2, 2006")
+		case "{date_long}":
+			return t.Format("Monday, ") + longDate(t)
+		case "{date_pretty}":
+			return prettyDate(t, now, longDate(t))
+		case "{date_short_pretty}":
+			return prettyDate(t, now, t.Format("Jan 2, 2006"))
+		case "{date_long_pretty}":
+			return prettyDate(t, now, t.Format("Monday, ")+longDate(t))
+		case "{time}":
+			return t.Format("3:04 PM")
+		case "{time_secs}":
+			return t.Format("3:04:05 PM")
+		case "{ago}":
+			return timeAgo(t, now)
+		}
+		ok = false
+		return placeholder
+	})
+	return slackUnescaper.Replace(formatted), ok
+}
+
+// A date like "February 18th, 2014"
+func longDate(t time.Time) string {
+	day := t.Day()
+	suffix := "th"
+	if day < 11 || day > 13 {
+		switch day % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%s %d%s, %d", t.Format("January"), day, suffix, t.Year())
+}
+
+// Whether two times fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// Today, yesterday, or tomorrow when the date is one of those, otherwise the given form
+func prettyDate(t, now time.Time, otherwise string) string {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch {
+	case sameDay(t, now):
+		return tr("date.today")
+	case day.Equal(today.AddDate(0, 0, -1)):
+		return tr("date.yesterday")
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return tr("date.tomorrow")
+	}
+	return otherwise
+}
+
+// How long ago a time was, e.g. "3 minutes ago", or how far off if it's in the future
+func timeAgo(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var amount string
+	switch {
+	case d < time.Minute:
+		return tr("date.just_now")
+	case d < time.Hour:
+		amount = plural(int(d/time.Minute), "date.minute", "date.minutes")
+	case d < 24*time.Hour:
+		amount = plural(int(d/time.Hour), "date.hour", "date.hours")
+	default:
+		amount = plural(int(d/(24*time.Hour)), "date.day", "date.days")
+	}
+
+	if future {
+		return fmt.Sprintf(tr("date.in"), amount)
+	}
+	return fmt.Sprintf(tr("date.ago"), amount)
+}
+
+// A count with the singular or plural form of its unit
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf(tr(one), n)
+	}
+	return fmt.Sprintf(tr(many), n)
+}
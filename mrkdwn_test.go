@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestRenderMessageTextMentions(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.userNames.set("U1", "alice")
+
+	tests := []struct {
+		name, text, want string
+	}{
+		{"user", "hi <@U1>", "hi @alice"},
+		{"user with label", "hi <@U1|someone>", "hi @alice"},
+		{"unknown user with label", "hi <@U9|bob>", "hi @bob"},
+		{"unknown user", "hi <@U9>", "hi @U9"},
+		{"channel", "see <#C2>", "see #random"},
+		{"channel with label", "see <#C9|ops>", "see #ops"},
+		{"unknown channel", "see <#C9>", "see #C9"},
+		{"broadcast", "<!here> standup", "@here standup"},
+		{"user group", "ping <!subteam^S1|@oncall>", "ping @oncall"},
+		{"link with label", "<https://example.com|the docs>", "the docs"},
+		{"bare link", "<https://example.com>", "https://example.com"},
+		{"mailto", "<mailto:a@example.com|mail me>", "mail me"},
+		{"empty user", "<@>", "<@>"},
+		{"empty channel", "<#>", "<#>"},
+		{"unclosed", "<@U1 said hi", "<@U1 said hi"},
+		{"escaped", "1 &lt; 2 &amp;&amp; 3 &gt; 2", "1 < 2 && 3 > 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.renderMessageText(tt.text); got != tt.want {
+				t.Errorf("renderMessageText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
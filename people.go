@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// Users fetched per users.list call
+	usersPageSize = 100
+
+	// Fetch the next page once the selection is this close to the end of the list
+	usersPrefetchMargin = 5
+)
+
+// PersonItem represents a workspace member in the people list
+type PersonItem struct {
+	user slack.User
+}
+
+// Implement the list.Item interface
+func (p PersonItem) Title() string {
+	if p.user.RealName != "" && p.user.RealName != p.user.Name {
+		return fmt.Sprintf("%s (@%s)", p.user.RealName, p.user.Name)
+	}
+	return "@" + p.user.Name
+}
+func (p PersonItem) Description() string { return p.user.Profile.Title }
+func (p PersonItem) FilterValue() string {
+	return p.user.Name + " " + p.user.RealName + " " + p.user.Profile.DisplayName
+}
+
+type usersPageMsg struct {
+	workspace string
+	page      slack.UserPagination
+	err       error
+}
+
+// Open the people page, fetching the first page of users unless they're cached
+func (m *Model) openPeople() tea.Cmd {
+	m.currentPage = pagePeople
+	if m.slackClient == nil || len(m.users) > 0 || m.usersComplete {
+		return nil
+	}
+
+	m.userPages = m.slackClient.GetUsersPaginated(slack.GetUsersOptionLimit(usersPageSize))
+	return m.fetchNextUsers()
+}
+
+// Fetch the next page of users if one isn't already in flight
+func (m *Model) fetchNextUsers() tea.Cmd {
+	if m.usersFetching || m.usersComplete {
+		return nil
+	}
+	m.usersFetching = true
+
+	page, workspace := m.userPages, m.workspace
+	return func() tea.Msg {
+		next, err := page.Next(context.Background())
+		return usersPageMsg{workspace: workspace, page: next, err: err}
+	}
+}
+
+// Add a fetched page of users to the cache and the people list
+func (m *Model) handleUsersPage(msg usersPageMsg) tea.Cmd {
+	// A page of a workspace since switched away from belongs to nobody now
+	if msg.workspace != m.workspace {
+		return nil
+	}
+	m.usersFetching = false
+
+	if msg.page.Done(msg.err) {
+		m.usersComplete = true
+		return nil
+	}
+	if msg.err != nil {
+		return m.showToast(fmt.Sprintf("%s: %v", tr("error.fetch_users"), msg.err), true)
+	}
+
+	m.userPages = msg.page
+	m.usersComplete = msg.page.Cursor == ""
+
+	items := m.people.Items()
+	for _, user := range msg.page.Users {
+		if user.Deleted {
+			continue
+		}
+		m.users = append(m.users, user)
+		m.userNames.set(user.ID, user.Name)
+		items = append(items, PersonItem{user: user})
+	}
+	cmds := []tea.Cmd{m.people.SetItems(items)}
+
+	// Searching needs the whole directory, so keep going until it's loaded
+	if m.people.FilterState() != list.Unfiltered {
+		cmds = append(cmds, m.fetchNextUsers())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Handle input on the people page, loading more users as the selection nears the end
+func (m *Model) updatePeople(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.people, cmd = m.people.Update(msg)
+	cmds := []tea.Cmd{cmd}
+
+	if m.people.FilterState() != list.Unfiltered {
+		cmds = append(cmds, m.fetchNextUsers())
+	} else if m.people.Index() >= len(m.people.Items())-usersPrefetchMargin {
+		cmds = append(cmds, m.fetchNextUsers())
+	}
+
+	return tea.Batch(cmds...)
+}
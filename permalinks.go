@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// Slack archive links, e.g. https://acme.slack.com/archives/C0123ABCD/p1700000000123456?thread_ts=1700000000.000100
+var permalinkPattern = regexp.MustCompile(`https://([a-z0-9-]+)\.slack\.com/archives/([A-Z0-9]+)/p(\d{16})(\?[^|>\s]*)?`)
+
+// A message permalink parsed into where it points
+type permalink struct {
+	url       string
+	domain    string
+	channelID string
+	timestamp string
+	threadTS  string
+}
+
+type threadMsg struct {
+	link     permalink
+	messages []SlackMessage
+}
+
+// Find the Slack message permalinks in a message's text
+func findPermalinks(text string) []permalink {
+	var links []permalink
+	for _, match := range permalinkPattern.FindAllStringSubmatch(text, -1) {
+		link := permalink{
+			url:       slackUnescaper.Replace(match[0]),
+			domain:    match[1],
+			channelID: match[2],
+			// The path encodes the timestamp without its dot
+			timestamp: match[3][:10] + "." + match[3][10:],
+		}
+
+		// Links to a thread reply carry the parent's timestamp
+		if match[4] != "" {
+			if query, err := url.ParseQuery(strings.TrimPrefix(slackUnescaper.Replace(match[4]), "?")); err == nil {
+				link.threadTS = query.Get("thread_ts")
+			}
+		}
+
+		links = append(links, link)
+	}
+	return links
+}
+
+// Name of a channel for display, falling back to its ID
+func (m Model) channelName(channelID string) string {
+	for _, ch := range m.channels {
+		if ch.ID == channelID {
+			return ch.Name
+		}
+	}
+	for _, ch := range m.directMessages {
+		if ch.ID == channelID {
+			return m.directName(ch)
+		}
+	}
+	return channelID
+}
+
+// A message's permalink built from the workspace domain, without asking Slack
+func (m Model) messagePermalink(msg SlackMessage) string {
+	if m.teamDomain == "" {
+		return ""
+	}
+
+	link := fmt.Sprintf("https://%s.slack.com/archives/%s/p%s", m.teamDomain, msg.ChannelID, strings.Replace(msg.Timestamp, ".", "", 1))
+	if msg.isThreadReply() {
+		link += fmt.Sprintf("?thread_ts=%s&cid=%s", msg.ThreadTS, msg.ChannelID)
+	}
+	return link
+}
+
+// Open a permalink inside the app, or in the browser when it points outside this workspace
+func (m *Model) followPermalink(link permalink) tea.Cmd {
+	if m.slackClient == nil || link.domain != m.teamDomain {
+		return openLinkCmd(link.url)
+	}
+
+	m.isLoading = true
+	return func() tea.Msg {
+		return m.fetchThread(link)
+	}
+}
+
+// Open a link in the browser
+func openLinkCmd(link string) tea.Cmd {
+	return func() tea.Msg {
+		if err := openURL(link); err != nil {
+			return actionResultMsg{text: tr("error.open_browser"), err: err}
+		}
+		return actionResultMsg{text: tr("toast.link_opened")}
+	}
+}
+
+// Open the thread a message starts or belongs to
+func (m *Model) openThread(msg SlackMessage) tea.Cmd {
+	m.currentPage = pageMessages
+	m.isLoading = true
+	link := permalink{channelID: msg.ChannelID, timestamp: msg.Timestamp, threadTS: msg.ThreadTS}
+	return func() tea.Msg {
+		return m.fetchThread(link)
+	}
+}
+
+// Load the thread a permalink points into, falling back to the browser if it can't be read
+func (m *Model) fetchThread(link permalink) tea.Msg {
+	parent := link.threadTS
+	if parent == "" {
+		parent = link.timestamp
+	}
+
+	replies, _, _, err := m.slackClient.GetConversationReplies(&slack.GetConversationRepliesParameters{
+		ChannelID: link.channelID,
+		Timestamp: parent,
+	})
+	if err != nil || len(replies) == 0 {
+		// Threads opened from a message rather than a link have nowhere else to go
+		if link.url == "" {
+			return actionResultMsg{text: tr("error.load_thread"), err: err}
+		}
+		return openLinkCmd(link.url)()
+	}
+
+	channel := m.channelName(link.channelID)
+
+	var messages []SlackMessage
+	for _, msg := range replies {
+		m.cacheMentionedUsers(msg.Text)
+		messages = append(messages, SlackMessage{
+			User:      m.messageAuthor(msg.Msg),
+			UserID:    msg.User,
+			Content:   msg.Text,
+			Channel:   channel,
+			ChannelID: link.channelID,
+			Timestamp: msg.Timestamp,
+			Time:      parseSlackTimestamp(msg.Timestamp),
+			Files:     msg.Files,
+			Edited:    msg.Edited,
+			IsStarred: msg.IsStarred,
+			Reactions: msg.Reactions,
+			ThreadTS:  msg.ThreadTimestamp,
+		})
+	}
+
+	return threadMsg{link: link, messages: messages}
+}
+
+// Show a fetched thread in the viewport, scrolled to the linked message
+func (m *Model) showThread(msg threadMsg) {
+	m.thread = msg.messages
+	m.threadLink = msg.link
+	m.currentPage = pageThread
+
+	var sb strings.Builder
+	top := 0
+	for _, message := range m.thread {
+		linked := message.Timestamp == msg.link.timestamp
+		if linked {
+			top = strings.Count(sb.String(), "\n")
+		}
+		sb.WriteString(m.renderMessage(message, linked))
+	}
+
+	m.viewport.SetContent(sb.String())
+	m.viewport.SetYOffset(top)
+}
+
+// Copy the open thread to the clipboard as a plain-text transcript: each
+// message's time, author, text, and files, with the replies indented under the parent
+func (m *Model) copyThread() tea.Cmd {
+	if len(m.thread) == 0 {
+		return m.showToast(tr("thread.empty"), true)
+	}
+
+	loc := m.configuredLocation()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(tr("thread.title"), m.channelName(m.threadLink.channelID)) + "\n\n")
+	for i, msg := range m.thread {
+		indent := ""
+		if i > 0 {
+			indent = "    "
+		}
+		sb.WriteString(fmt.Sprintf("%s[%s] %s:\n", indent, msg.Time.In(loc).Format("2006-01-02 15:04"), msg.User))
+		text := m.renderMrkdwn(msg.Content)
+		for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+			sb.WriteString(indent + "  " + line + "\n")
+		}
+		for _, f := range msg.Files {
+			sb.WriteString(indent + "  " + fmt.Sprintf(tr("thread.transcript_file"), fileName(f)) + "\n")
+		}
+	}
+
+	transcript := sb.String()
+	confirmation := fmt.Sprintf(tr("toast.thread_copied"), len(m.thread))
+	return func() tea.Msg {
+		return copyToClipboard(transcript, confirmation)
+	}
+}
+
+// Let the user pick which of a message's links to follow
+func (m *Model) openLinkPicker(links []permalink) {
+	items := make([]list.Item, len(links))
+	for i, link := range links {
+		items[i] = QuickAction{
+			id:          strconv.Itoa(i),
+			name:        fmt.Sprintf("#%s %s", m.channelName(link.channelID), parseSlackTimestamp(link.timestamp).Format("2006-01-02 15:04")),
+			description: link.url,
+		}
+	}
+	m.linkOptions.SetItems(items)
+	m.linkOptions.SetHeight(len(items) + 4)
+	m.linkOptions.Select(0)
+	m.currentPage = pageLinks
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestOpenThreadFetchesReplies(t *testing.T) {
+	parent := testMessage("U1", "deploy today?", "1700000001.000000")
+	reply := testMessage("U2", "yes, at 3", "1700000002.000000")
+	reply.ThreadTimestamp = parent.Timestamp
+	client := &fakeSlack{
+		users:   map[string]string{"U1": "alice", "U2": "bob"},
+		replies: map[string][]slack.Message{parent.Timestamp: {parent, reply}},
+	}
+	m := newTestModel(client)
+
+	// Opening from a reply reads the whole thread from its parent
+	msgs := runCmd(m.openThread(SlackMessage{ChannelID: "C3", Timestamp: reply.Timestamp, ThreadTS: parent.Timestamp}))
+	if len(msgs) != 1 {
+		t.Fatalf("openThread produced %d messages, want 1", len(msgs))
+	}
+	msg, ok := msgs[0].(threadMsg)
+	if !ok {
+		t.Fatalf("openThread produced %T, want threadMsg", msgs[0])
+	}
+	equalCalls(t, client.called(), []string{`GetConversationReplies("C3", "1700000001.000000")`})
+
+	if len(msg.messages) != 2 || msg.messages[0].Content != "deploy today?" || msg.messages[1].User != "bob" {
+		t.Errorf("thread = %+v, want the parent then bob's reply", msg.messages)
+	}
+	if msg.link.timestamp != reply.Timestamp {
+		t.Errorf("link.timestamp = %q, want the opened reply's", msg.link.timestamp)
+	}
+}
+
+func TestOpenThreadEmpty(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+
+	msgs := runCmd(m.openThread(SlackMessage{ChannelID: "C3", Timestamp: "1700000001.000000"}))
+	if len(msgs) != 1 {
+		t.Fatalf("openThread produced %d messages, want 1", len(msgs))
+	}
+	if msg, ok := msgs[0].(actionResultMsg); !ok || msg.text != tr("error.load_thread") {
+		t.Errorf("openThread = %+v, want the load error", msgs[0])
+	}
+}
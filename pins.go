@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A message pinned to a channel, reduced to what the preview shows
+type pinnedMessage struct {
+	user string
+	text string
+}
+
+type pinsMsg struct {
+	channelID string
+	pins      []pinnedMessage
+}
+
+// Fetch the messages pinned to a channel for the preview above its messages.
+// Without the pins:read scope the preview just stays hidden.
+func (m *Model) fetchPins(channelID string) tea.Cmd {
+	if channelID == "" || m.slackClient == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		items, _, err := m.slackClient.ListPins(channelID)
+		if err != nil {
+			return pinsMsg{channelID: channelID}
+		}
+
+		var pins []pinnedMessage
+		for _, item := range items {
+			if item.Message == nil {
+				continue
+			}
+			pins = append(pins, pinnedMessage{user: m.messageAuthor(item.Message.Msg), text: item.Message.Text})
+		}
+		return pinsMsg{channelID: channelID, pins: pins}
+	}
+}
+
+// Keep the pins for the channel still on screen
+func (m *Model) handlePins(msg pinsMsg) {
+	if msg.channelID != m.selectedChannelID {
+		return
+	}
+	m.pinsChannelID = msg.channelID
+	m.pins = msg.pins
+	m.refreshMessages()
+}
+
+// Expand or collapse the pinned messages, for the rest of the session
+func (m *Model) togglePins() {
+	m.pinsExpanded = !m.pinsExpanded
+	m.refreshMessages()
+}
+
+// The pinned messages of the selected channel: a single line while collapsed,
+// one line per message once expanded
+func (m Model) pinnedPreview() string {
+	if m.pinsChannelID != m.selectedChannelID || len(m.pins) == 0 {
+		return ""
+	}
+
+	summary := fmt.Sprintf(tr("pins.summary"), len(m.pins))
+	if !m.pinsExpanded {
+		return helpStyle.Render(summary + "  " + tr("pins.expand"))
+	}
+
+	width := m.viewport.Width - m.viewport.Style.GetHorizontalFrameSize() - 4
+	lines := []string{infoStyle.Render(summary)}
+	for _, pin := range m.pins {
+		text, _, _ := strings.Cut(m.renderEmoji(pin.text), "\n")
+		lines = append(lines, "  "+truncate(pin.user+": "+text, width))
+	}
+	return strings.Join(lines, "\n")
+}
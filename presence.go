@@ -0,0 +1,178 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// Orders of the channel picker
+const (
+	channelSortName     = "name"
+	channelSortPresence = "presence"
+)
+
+// Direct messages whose presence is kept fresh, to stay well inside the rate limit
+const presenceFetchLimit = 30
+
+var channelSorts = []string{channelSortName, channelSortPresence}
+
+type presenceRefreshMsg struct {
+	seq int
+}
+
+type directNamesMsg struct{}
+
+// Name of the person a direct message is with, e.g. "@alice", from the user cache.
+// A group direct message lists everyone else in it.
+func (m Model) directName(ch slack.Channel) string {
+	if ch.IsMpIM {
+		return m.groupDirectName(ch)
+	}
+	if name, ok := m.userNames.get(ch.User); ok {
+		return "@" + name
+	}
+	return "@" + ch.User
+}
+
+// Names in a group direct message, read from its name like "mpdm-alice--bob--carol-1"
+func (m Model) groupDirectName(ch slack.Channel) string {
+	members := strings.TrimPrefix(ch.Name, "mpdm-")
+	if i := strings.LastIndex(members, "-"); i > 0 {
+		members = members[:i]
+	}
+
+	var names []string
+	for _, name := range strings.Split(members, "--") {
+		if name != "" && name != m.userName {
+			names = append(names, "@"+name)
+		}
+	}
+	if len(names) == 0 {
+		return ch.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Place of a presence in the picker's presence order: active, then away, then still unknown
+func presenceRank(presence string) int {
+	switch presence {
+	case "active":
+		return 0
+	case "away":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Order direct messages so the people who are active right now come first
+func (m Model) sortByPresence(dms []slack.Channel) {
+	sort.SliceStable(dms, func(i, j int) bool {
+		return presenceRank(m.presence[dms[i].User]) < presenceRank(m.presence[dms[j].User])
+	})
+}
+
+// Switch the picker between name and presence order
+func (m *Model) toggleChannelSort() tea.Cmd {
+	if m.channelSort == channelSortPresence {
+		m.channelSort = channelSortName
+	} else {
+		m.channelSort = channelSortPresence
+	}
+	return tea.Batch(m.filterChannels(), m.startPresenceRefresh())
+}
+
+// The picker's title, naming the order it's in
+func (m Model) channelListTitle() string {
+	if m.channelSort == channelSortPresence {
+		return tr("channels.title_presence")
+	}
+	return tr("channels.title")
+}
+
+// Look up the names of the people in direct messages that aren't cached yet, in one request
+func (m *Model) fetchDirectNames() tea.Cmd {
+	if m.slackClient == nil {
+		return nil
+	}
+
+	var ids []string
+	for _, ch := range m.directMessages {
+		if _, ok := m.userNames.get(ch.User); !ok && ch.User != "" {
+			ids = append(ids, ch.User)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		users, err := m.slackClient.GetUsersInfo(ids...)
+		if err != nil {
+			return nil
+		}
+		for _, user := range *users {
+			m.userNames.set(user.ID, user.Name)
+		}
+		return directNamesMsg{}
+	}
+}
+
+// Fetch the presence of the people in direct messages, whether or not it's been seen before
+func (m *Model) fetchDirectPresence() tea.Cmd {
+	if m.slackClient == nil || len(m.directMessages) == 0 {
+		return nil
+	}
+
+	// Group direct messages have no one person's presence to show
+	var ids []string
+	for _, ch := range m.directMessages {
+		if len(ids) == presenceFetchLimit {
+			break
+		}
+		if ch.User != "" {
+			ids = append(ids, ch.User)
+		}
+	}
+
+	return func() tea.Msg {
+		presence := make(map[string]string, len(ids))
+		for _, id := range ids {
+			p, err := m.slackClient.GetUserPresence(id)
+			if err != nil {
+				continue
+			}
+			presence[id] = p.Presence
+		}
+		return presenceMsg{presence: presence}
+	}
+}
+
+// Fetch presence now and schedule the next refresh, while the picker is in presence order
+func (m *Model) startPresenceRefresh() tea.Cmd {
+	m.presenceRefreshSeq++
+	if m.channelSort != channelSortPresence {
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.fetchDirectPresence()}
+	if m.config.PresenceRefreshMinutes > 0 {
+		seq := m.presenceRefreshSeq
+		cmds = append(cmds, tea.Tick(time.Duration(m.config.PresenceRefreshMinutes)*time.Minute, func(time.Time) tea.Msg {
+			return presenceRefreshMsg{seq: seq}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// Refresh presence on schedule, stopping once the picker is closed
+func (m *Model) handlePresenceRefresh(msg presenceRefreshMsg) tea.Cmd {
+	if msg.seq != m.presenceRefreshSeq || m.currentPage != pageChannelList {
+		return nil
+	}
+	return m.startPresenceRefresh()
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+// Slack's published rate limit tiers, in requests per minute
+const (
+	tier1 = 1
+	tier2 = 20
+	tier3 = 50
+	tier4 = 100
+
+	// chat.postMessage is limited separately to roughly one message per second
+	tierPostMessage = 60
+)
+
+// Rate limit tier of each Slack method the app calls. Unlisted methods default to tier 3.
+var methodTiers = map[string]int{
+	"auth.test":             tier4,
+	"rtm.connect":           tier1,
+	"conversations.list":    tier2,
+	"conversations.history": tier3,
+	"conversations.replies": tier3,
+	"conversations.members": tier4,
+	"conversations.info":    tier3,
+	"conversations.mark":    tier3,
+	"users.info":            tier4,
+	"users.getPresence":     tier3,
+	"users.list":            tier2,
+	"users.setPresence":     tier2,
+	"users.profile.set":     tier3,
+	"chat.postMessage":      tierPostMessage,
+	"chat.update":           tier3,
+	"chat.delete":           tier3,
+	"chat.getPermalink":     tier4,
+	"reactions.add":         tier3,
+	"reactions.remove":      tier3,
+	"pins.add":              tier2,
+}
+
+// tokenBucket allows short bursts while holding the sustained rate to the tier's limit
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	perSec   float64
+	last     time.Time
+}
+
+func newTokenBucket(perMinute int) *tokenBucket {
+	capacity := float64(perMinute) / 10
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &tokenBucket{
+		tokens:   capacity,
+		capacity: capacity,
+		perSec:   float64(perMinute) / 60,
+		last:     time.Now(),
+	}
+}
+
+// Take a token, returning how long the caller must wait before using it
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.perSec
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.perSec * float64(time.Second))
+}
+
+// rateLimitedClient spaces out Slack API requests per method family before they're sent
+type rateLimitedClient struct {
+	next    *http.Client
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+func newRateLimitedClient(next *http.Client) *rateLimitedClient {
+	return &rateLimitedClient{
+		next:    next,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// The bucket for a method, created on first use
+func (c *rateLimitedClient) bucket(method string) *tokenBucket {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.buckets[method]
+	if !ok {
+		tier, known := methodTiers[method]
+		if !known {
+			tier = tier3
+		}
+		b = newTokenBucket(tier)
+		c.buckets[method] = b
+	}
+	return b
+}
+
+// Do waits for the method's rate limit, then sends the request
+func (c *rateLimitedClient) Do(req *http.Request) (*http.Response, error) {
+	// API URLs end in the method name, e.g. https://slack.com/api/conversations.history
+	wait := c.bucket(path.Base(req.URL.Path)).reserve()
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	return c.next.Do(req)
+}
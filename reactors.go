@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Names listed per reaction before the rest are counted instead
+const reactorNameLimit = 5
+
+// Widest the who-reacted overlay grows to
+const reactorListWidth = 60
+
+type reactorsMsg struct {
+	items []list.Item
+}
+
+// Resolve the names of the people behind each of a message's reactions
+func (m *Model) fetchReactors(selected SlackMessage) tea.Cmd {
+	return func() tea.Msg {
+		items := make([]list.Item, len(selected.Reactions))
+		for i, reaction := range selected.Reactions {
+			var names []string
+			for _, user := range reaction.Users {
+				if len(names) == reactorNameLimit {
+					break
+				}
+				names = append(names, m.lookupUserName(user))
+			}
+
+			// Slack caps the users it returns, so the count covers anyone left out
+			who := strings.Join(names, ", ")
+			if more := max(reaction.Count, len(reaction.Users)) - len(names); more > 0 {
+				who += " " + fmt.Sprintf(tr("reactors.more"), more)
+			}
+
+			chip := m.renderEmoji(":" + reaction.Name + ":")
+			items[i] = QuickAction{id: reaction.Name, name: fmt.Sprintf("%s %d  %s", chip, reaction.Count, who)}
+		}
+		return reactorsMsg{items: items}
+	}
+}
+
+// Show who reacted, one line per reaction
+func (m *Model) openReactors(msg reactorsMsg) {
+	width, _ := m.chromeContentSize()
+	m.reactorOptions.SetItems(msg.items)
+	m.reactorOptions.SetSize(min(max(width-4, 0), reactorListWidth), len(msg.items)+4)
+	m.reactorOptions.Select(0)
+	m.currentPage = pageReactors
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// Members fetched per conversations.members call
+const rosterPageSize = 100
+
+// RosterItem represents a channel member in the member list
+type RosterItem struct {
+	user     slack.User
+	presence string
+}
+
+// Implement the list.Item interface
+func (r RosterItem) Title() string {
+	name := "@" + r.user.Name
+	if r.user.RealName != "" && r.user.RealName != r.user.Name {
+		name = fmt.Sprintf("%s (@%s)", r.user.RealName, r.user.Name)
+	}
+	return presenceDot(r.presence) + " " + name
+}
+func (r RosterItem) Description() string { return r.user.Profile.Title }
+func (r RosterItem) FilterValue() string { return r.user.Name + " " + r.user.RealName }
+
+// Colored dot for a presence, hollow while it's still unknown
+func presenceDot(presence string) string {
+	switch presence {
+	case "active":
+		return statusActiveStyle.Render("●")
+	case "away":
+		return infoStyle.Render("●")
+	default:
+		return infoStyle.Render("○")
+	}
+}
+
+type rosterPageMsg struct {
+	channelID string
+	users     []slack.User
+	cursor    string
+	err       error
+}
+
+type rosterCountMsg struct {
+	channelID string
+	count     int
+}
+
+type presenceMsg struct {
+	presence map[string]string
+}
+
+// Open the member list for a channel and load its first page
+func (m *Model) openRoster(channelID string) tea.Cmd {
+	m.rosterChannelID = channelID
+	m.rosterCursor = ""
+	m.rosterFetching = false
+	m.rosterComplete = false
+	m.rosterCount = 0
+	m.roster.ResetFilter()
+	m.roster.Select(0)
+	m.roster.Title = m.rosterTitle()
+	m.currentPage = pageRoster
+
+	return tea.Batch(
+		m.roster.SetItems(nil),
+		m.fetchRosterCount(channelID),
+		m.fetchNextRoster(),
+	)
+}
+
+// Header for the member list, with the channel's member count once it's known
+func (m Model) rosterTitle() string {
+	name := m.rosterChannelID
+	for _, ch := range m.channels {
+		if ch.ID == m.rosterChannelID {
+			name = ch.Name
+			break
+		}
+	}
+	if m.rosterCount == 0 {
+		return fmt.Sprintf(tr("roster.title"), name)
+	}
+	return fmt.Sprintf(tr("roster.title_count"), name, m.rosterCount)
+}
+
+// Look up how many members the channel has
+func (m *Model) fetchRosterCount(channelID string) tea.Cmd {
+	if m.slackClient == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		info, err := m.slackClient.GetConversationInfo(&slack.GetConversationInfoInput{
+			ChannelID:         channelID,
+			IncludeNumMembers: true,
+		})
+		if err != nil {
+			// The count is only a nicety, so leave it out
+			return nil
+		}
+		return rosterCountMsg{channelID: channelID, count: info.NumMembers}
+	}
+}
+
+// Fetch the next page of members if one isn't already in flight
+func (m *Model) fetchNextRoster() tea.Cmd {
+	if m.rosterFetching || m.rosterComplete || m.slackClient == nil {
+		return nil
+	}
+	m.rosterFetching = true
+
+	// Resolve names from the people cache, looking up the rest
+	known := make(map[string]slack.User, len(m.users))
+	for _, user := range m.users {
+		known[user.ID] = user
+	}
+
+	channelID := m.rosterChannelID
+	cursor := m.rosterCursor
+	return func() tea.Msg {
+		ids, next, err := m.slackClient.GetUsersInConversation(&slack.GetUsersInConversationParameters{
+			ChannelID: channelID,
+			Cursor:    cursor,
+			Limit:     rosterPageSize,
+		})
+		if err != nil {
+			return rosterPageMsg{channelID: channelID, err: err}
+		}
+
+		var users []slack.User
+		var unknown []string
+		for _, id := range ids {
+			if user, ok := known[id]; ok {
+				users = append(users, user)
+			} else {
+				unknown = append(unknown, id)
+			}
+		}
+		if len(unknown) > 0 {
+			fetched, err := m.slackClient.GetUsersInfo(unknown...)
+			if err != nil {
+				return rosterPageMsg{channelID: channelID, err: err}
+			}
+			users = append(users, *fetched...)
+		}
+
+		return rosterPageMsg{channelID: channelID, users: users, cursor: next}
+	}
+}
+
+// Add a fetched page of members to the list
+func (m *Model) handleRosterPage(msg rosterPageMsg) tea.Cmd {
+	// Drop pages for a channel the user has since left
+	if msg.channelID != m.rosterChannelID {
+		return nil
+	}
+	m.rosterFetching = false
+
+	if msg.err != nil {
+		m.rosterComplete = true
+		return m.showToast(fmt.Sprintf("%s: %v", tr("error.channel_members"), msg.err), true)
+	}
+
+	m.rosterCursor = msg.cursor
+	m.rosterComplete = msg.cursor == ""
+
+	items := m.roster.Items()
+	for _, user := range msg.users {
+		if user.Deleted {
+			continue
+		}
+		items = append(items, RosterItem{user: user, presence: m.presence[user.ID]})
+	}
+
+	cmds := []tea.Cmd{m.roster.SetItems(items), m.fetchVisiblePresence()}
+
+	// Searching needs every member, so keep going until they're all loaded
+	if m.roster.FilterState() != list.Unfiltered {
+		cmds = append(cmds, m.fetchNextRoster())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Fetch the presence of the members on screen that haven't been looked up yet
+func (m *Model) fetchVisiblePresence() tea.Cmd {
+	if m.slackClient == nil {
+		return nil
+	}
+
+	visible := m.roster.VisibleItems()
+	start, end := m.roster.Paginator.GetSliceBounds(len(visible))
+
+	var ids []string
+	for _, item := range visible[start:end] {
+		member, ok := item.(RosterItem)
+		if !ok || m.presenceRequested[member.user.ID] {
+			continue
+		}
+		m.presenceRequested[member.user.ID] = true
+		ids = append(ids, member.user.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		presence := make(map[string]string, len(ids))
+		for _, id := range ids {
+			p, err := m.slackClient.GetUserPresence(id)
+			if err != nil {
+				continue
+			}
+			presence[id] = p.Presence
+		}
+		return presenceMsg{presence: presence}
+	}
+}
+
+// Record fetched presence and redraw the members it belongs to
+func (m *Model) handlePresence(msg presenceMsg) {
+	for id, presence := range msg.presence {
+		m.presence[id] = presence
+	}
+
+	for i, item := range m.roster.Items() {
+		member, ok := item.(RosterItem)
+		if !ok {
+			continue
+		}
+		if presence, ok := msg.presence[member.user.ID]; ok {
+			member.presence = presence
+			m.roster.SetItem(i, member)
+		}
+	}
+}
+
+// Handle input on the member list, loading more members as the selection nears the end
+func (m *Model) updateRoster(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.roster, cmd = m.roster.Update(msg)
+	cmds := []tea.Cmd{cmd, m.fetchVisiblePresence()}
+
+	if m.roster.FilterState() != list.Unfiltered {
+		cmds = append(cmds, m.fetchNextRoster())
+	} else if m.roster.Index() >= len(m.roster.Items())-usersPrefetchMargin {
+		cmds = append(cmds, m.fetchNextRoster())
+	}
+
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// How often the scheduler checks whether a rule is due. Polling the clock
+// rather than sleeping until the next rule keeps it on time after the
+// machine wakes from sleep or the clock is adjusted.
+const scheduleCheckInterval = 30 * time.Second
+
+// StatusRule is a recurring status change, e.g. every weekday at 9:00 set "🏠 Working from home"
+type StatusRule struct {
+	// "mon" through "sun", "weekdays", or "weekends"; empty means every day
+	Days []string `json:"days"`
+
+	// Wall-clock time in the schedule's timezone, as "15:04"
+	At string `json:"at"`
+
+	// Presence to switch to ("active", "away", "dnd"), or empty to leave it alone
+	Presence string `json:"presence"`
+
+	// Custom status to set, e.g. "Working from home" and ":house_with_garden:"
+	Text  string `json:"text"`
+	Emoji string `json:"emoji"`
+}
+
+// Weekdays accepted in a rule's days
+var ruleDays = map[string][]time.Weekday{
+	"mon":      {time.Monday},
+	"tue":      {time.Tuesday},
+	"wed":      {time.Wednesday},
+	"thu":      {time.Thursday},
+	"fri":      {time.Friday},
+	"sat":      {time.Saturday},
+	"sun":      {time.Sunday},
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+}
+
+// Check a rule's fields, returning what's wrong with it or "" if it's usable
+func (r StatusRule) problem() string {
+	if _, err := time.Parse("15:04", r.At); err != nil {
+		return fmt.Sprintf(tr("config.rule_time"), r.At)
+	}
+	for _, day := range r.Days {
+		if _, ok := ruleDays[strings.ToLower(day)]; !ok {
+			return fmt.Sprintf(tr("config.rule_day"), day)
+		}
+	}
+	if r.Presence != "" && !validPresence(r.Presence) {
+		return fmt.Sprintf(tr("config.rule_presence"), r.Presence)
+	}
+	if r.Presence == "" && r.Text == "" && r.Emoji == "" {
+		return tr("config.rule_empty")
+	}
+	return ""
+}
+
+// Whether the rule applies on the given weekday
+func (r StatusRule) onDay(day time.Weekday) bool {
+	if len(r.Days) == 0 {
+		return true
+	}
+	for _, name := range r.Days {
+		for _, d := range ruleDays[strings.ToLower(name)] {
+			if d == day {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// The first time after now that the rule fires. Each candidate is built from
+// the calendar date in loc, so the rule keeps its wall-clock time across DST
+// changes; a time skipped by a spring-forward fires just after the jump.
+func (r StatusRule) next(now time.Time, loc *time.Location) time.Time {
+	at, err := time.Parse("15:04", r.At)
+	if err != nil {
+		return time.Time{}
+	}
+
+	local := now.In(loc)
+	for i := 0; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+		if candidate.After(now) && r.onDay(candidate.Weekday()) {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
+
+// Short description of what the rule sets, e.g. "🏠 Working from home"
+func (r StatusRule) label() string {
+	if custom := strings.TrimSpace(r.Emoji + " " + r.Text); custom != "" {
+		return custom
+	}
+	return statusLabel(r.Presence)
+}
+
+type scheduleTickMsg struct {
+	seq int
+}
+
+type scheduledStatusMsg struct {
+	rule StatusRule
+	err  error
+}
+
+// The configured timezone, falling back to the system's
+func (m Model) configuredLocation() *time.Location {
+	if m.config.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(m.config.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// Pick the rule that fires soonest after now
+func (m *Model) planNextRule(now time.Time) {
+	m.nextRuleAt = time.Time{}
+	loc := m.configuredLocation()
+	for _, rule := range m.config.StatusSchedule {
+		at := rule.next(now, loc)
+		if at.IsZero() {
+			continue
+		}
+		if m.nextRuleAt.IsZero() || at.Before(m.nextRuleAt) {
+			m.nextRule = rule
+			m.nextRuleAt = at
+		}
+	}
+}
+
+// Wait for the next scheduler check, if there are rules to check
+func (m Model) scheduleTick() tea.Cmd {
+	if len(m.config.StatusSchedule) == 0 {
+		return nil
+	}
+	seq := m.scheduleSeq
+	return tea.Tick(scheduleCheckInterval, func(time.Time) tea.Msg {
+		return scheduleTickMsg{seq: seq}
+	})
+}
+
+// Restart the scheduler from the current time
+func (m *Model) startSchedule() tea.Cmd {
+	m.scheduleSeq++
+	m.planNextRule(time.Now())
+	return m.scheduleTick()
+}
+
+// Apply the next rule if it's due, then keep the scheduler going
+func (m *Model) handleScheduleTick(msg scheduleTickMsg) tea.Cmd {
+	// Ignore checks from before the schedule was paused or restarted
+	if msg.seq != m.scheduleSeq || !m.scheduleEnabled {
+		return nil
+	}
+
+	cmds := []tea.Cmd{m.scheduleTick()}
+
+	now := time.Now()
+	if !m.nextRuleAt.IsZero() && !now.Before(m.nextRuleAt) {
+		rule := m.nextRule
+		if m.slackClient != nil {
+			cmds = append(cmds, func() tea.Msg {
+				return m.applyStatusRule(rule)
+			})
+		}
+		m.planNextRule(now)
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// Pause or resume all recurring status changes
+func (m *Model) toggleSchedule() tea.Cmd {
+	m.scheduleEnabled = !m.scheduleEnabled
+	if !m.scheduleEnabled {
+		m.scheduleSeq++
+		return m.showToast(tr("toast.schedule_paused"), false)
+	}
+	return tea.Batch(m.startSchedule(), m.showToast(tr("toast.schedule_resumed"), false))
+}
+
+// Set the presence and custom status from a rule
+func (m *Model) applyStatusRule(rule StatusRule) tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	if presence, ok := slackPresence(rule.Presence); ok {
+		if err := m.slackClient.SetUserPresence(presence); err != nil {
+			return scheduledStatusMsg{rule: rule, err: err}
+		}
+	}
+	if rule.Text != "" || rule.Emoji != "" {
+		if err := m.slackClient.SetUserCustomStatus(rule.Text, rule.Emoji, 0); err != nil {
+			return scheduledStatusMsg{rule: rule, err: err}
+		}
+	}
+
+	return scheduledStatusMsg{rule: rule}
+}
+
+// Line on the main page describing the next scheduled change
+func (m Model) scheduleSummary() string {
+	if len(m.config.StatusSchedule) == 0 {
+		return ""
+	}
+	if !m.scheduleEnabled {
+		return fmt.Sprintf(tr("schedule.paused"), m.config.Keys.ToggleSchedule)
+	}
+	if m.nextRuleAt.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(tr("schedule.next"), m.nextRuleAt.Format("Mon 15:04 MST"), m.nextRule.label())
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestApplyStatusRulePresence(t *testing.T) {
+	tests := []struct {
+		rule StatusRule
+		want []string
+	}{
+		{
+			rule: StatusRule{At: "09:00", Presence: statusActive, Text: "Working from home", Emoji: ":house:"},
+			want: []string{`SetUserPresence("auto")`, `SetUserCustomStatus("Working from home", ":house:", 0)`},
+		},
+		{
+			rule: StatusRule{At: "18:00", Presence: statusAway},
+			want: []string{`SetUserPresence("away")`},
+		},
+		{
+			rule: StatusRule{At: "13:00", Presence: statusDND, Text: "Focus time"},
+			want: []string{`SetUserCustomStatus("Focus time", "", 0)`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule.At, func(t *testing.T) {
+			client := &fakeSlack{}
+			m := newTestModel(client)
+
+			msg, ok := m.applyStatusRule(tt.rule).(scheduledStatusMsg)
+			if !ok || msg.err != nil {
+				t.Fatalf("applyStatusRule = %+v, want a successful scheduledStatusMsg", msg)
+			}
+			equalCalls(t, client.called(), tt.want)
+		})
+	}
+}
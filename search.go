@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Start typing a search of the loaded messages, picking up the one already applied
+func (m *Model) openSearch() tea.Cmd {
+	m.searching = true
+	m.searchInput.SetValue(m.searchQuery)
+	m.searchInput.CursorEnd()
+	return m.searchInput.Focus()
+}
+
+// Handle a key while typing the search: enter keeps the matches, esc drops the
+// search, and everything else edits it, narrowing the messages as you type
+func (m *Model) updateSearch(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+		m.searchInput.Blur()
+		return nil
+	case "esc":
+		m.clearSearch()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	if query := strings.TrimSpace(m.searchInput.Value()); query != m.searchQuery {
+		m.searchQuery = query
+		m.selectedMessage = 0
+		m.refreshMessages()
+		m.viewport.GotoTop()
+	}
+	return cmd
+}
+
+// Drop the search, showing all the loaded messages again
+func (m *Model) clearSearch() {
+	m.searching = false
+	m.searchInput.Blur()
+	m.searchInput.Reset()
+	if m.searchQuery != "" {
+		m.searchQuery = ""
+		m.refreshMessages()
+	}
+}
+
+// Whether a message's author or text contains the search, ignoring case
+func (m Model) matchesSearch(msg SlackMessage) bool {
+	if m.searchQuery == "" {
+		return true
+	}
+	query := strings.ToLower(m.searchQuery)
+	return strings.Contains(strings.ToLower(msg.User), query) ||
+		strings.Contains(strings.ToLower(m.renderMessageText(msg.Content)), query)
+}
+
+// Mark each occurrence of the search in rendered text
+func (m Model) highlightSearch(text string) string {
+	if m.searchQuery == "" {
+		return text
+	}
+
+	// Byte offsets in the lowered text only line up with the original when lowering keeps the lengths
+	lower := strings.ToLower(text)
+	query := strings.ToLower(m.searchQuery)
+	if len(lower) != len(text) {
+		return text
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:i])
+		b.WriteString(searchMatchStyle.Render(text[i : i+len(query)]))
+		text, lower = text[i+len(query):], lower[i+len(query):]
+	}
+}
+
+// The status line while searching: the input, or the matches for an applied search
+func (m Model) searchStatus() string {
+	if m.searching {
+		return m.searchInput.View()
+	}
+	return fmt.Sprintf(tr("search.matches"), m.searchQuery, len(m.visibleMessages()))
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSearchFiltersMessages(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.currentPage = pageMessages
+	m.selectedChannelID = "C1"
+	m.messages = []SlackMessage{
+		{ChannelID: "C1", User: "alice", Content: "Deploy is done", Timestamp: "1700000001.000000"},
+		{ChannelID: "C1", User: "bob", Content: "lunch?", Timestamp: "1700000002.000000"},
+		{ChannelID: "C1", User: "carol", Content: "who's deploying next", Timestamp: "1700000003.000000"},
+	}
+	m.refreshMessages()
+
+	m.openSearch()
+	for _, r := range "DEPLOY" {
+		m.updateSearch(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m.updateSearch(tea.KeyMsg{Type: tea.KeyEnter})
+
+	visible := m.visibleMessages()
+	if len(visible) != 2 || visible[0].User != "alice" || visible[1].User != "carol" {
+		t.Errorf("visible = %+v, want alice's and carol's messages", visible)
+	}
+
+	m.clearSearch()
+	if got := len(m.visibleMessages()); got != 3 {
+		t.Errorf("%d messages visible after clearing the search, want 3", got)
+	}
+}
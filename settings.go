@@ -0,0 +1,403 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Kinds of setting, which decide how enter edits them
+const (
+	settingBool   = "bool"
+	settingChoice = "choice"
+	settingText   = "text"
+	settingNumber = "number"
+)
+
+// setting is one option on the settings page, addressed by its path in the config file
+type setting struct {
+	key     string
+	kind    string
+	options []string
+	get     func(Config) string
+	set     func(*Config, string) error
+}
+
+// Options shown on the settings page, in order
+var settings = []setting{
+	{key: "locale", kind: settingChoice, options: []string{"en", "es"},
+		get: func(c Config) string { return c.Locale },
+		set: func(c *Config, v string) error { c.Locale = v; return nil }},
+	{key: "newestFirst", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.NewestFirst) },
+		set: func(c *Config, v string) error { c.NewestFirst = v == "true"; return nil }},
+	{key: "startupBanner", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.StartupBanner) },
+		set: func(c *Config, v string) error { c.StartupBanner = v == "true"; return nil }},
+	{key: "skipStartupFetch", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.SkipStartupFetch) },
+		set: func(c *Config, v string) error { c.SkipStartupFetch = v == "true"; return nil }},
+	{key: "workspacePrefix", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.WorkspacePrefix) },
+		set: func(c *Config, v string) error { c.WorkspacePrefix = v == "true"; return nil }},
+	{key: "showApiWarnings", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.ShowAPIWarnings) },
+		set: func(c *Config, v string) error { c.ShowAPIWarnings = v == "true"; return nil }},
+	{key: "focusMode", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.FocusMode) },
+		set: func(c *Config, v string) error { c.FocusMode = v == "true"; return nil }},
+	{key: "confirmQuit", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.ConfirmQuit) },
+		set: func(c *Config, v string) error { c.ConfirmQuit = v == "true"; return nil }},
+	{key: "markReadOnView", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.MarkReadOnView) },
+		set: func(c *Config, v string) error { c.MarkReadOnView = v == "true"; return nil }},
+	{key: "markReadDelaySeconds", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.MarkReadDelaySeconds) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.MarkReadDelaySeconds) }},
+	{key: "scrollLines", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.ScrollLines) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.ScrollLines) }},
+	{key: "catchUpWindowHours", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.CatchUpWindowHours) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.CatchUpWindowHours) }},
+	{key: "awayAfterIdleMinutes", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.AwayAfterIdleMinutes) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.AwayAfterIdleMinutes) }},
+	{key: "timeoutSeconds", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.TimeoutSeconds) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.TimeoutSeconds) }},
+	{key: "allChannelsLimit", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.AllChannelsLimit) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 1, &c.AllChannelsLimit) }},
+	{key: "singleChannelLimit", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.SingleChannelLimit) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 1, &c.SingleChannelLimit) }},
+	{key: "historyHours", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.HistoryHours) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.HistoryHours) }},
+	{key: "liveRenderIntervalMs", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.LiveRenderIntervalMs) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 1, &c.LiveRenderIntervalMs) }},
+	{key: "sparklines", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.Sparklines) },
+		set: func(c *Config, v string) error { c.Sparklines = v == "true"; return nil }},
+	{key: "sparklineHours", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.SparklineHours) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 1, &c.SparklineHours) }},
+	{key: "channelSort", kind: settingChoice, options: channelSorts,
+		get: func(c Config) string { return c.ChannelSort },
+		set: func(c *Config, v string) error { c.ChannelSort = v; return nil }},
+	{key: "afterSend", kind: settingChoice, options: afterSendOptions,
+		get: func(c Config) string { return c.AfterSend },
+		set: func(c *Config, v string) error { c.AfterSend = v; return nil }},
+	{key: "presenceRefreshMinutes", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.PresenceRefreshMinutes) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.PresenceRefreshMinutes) }},
+	{key: "prefetchUsers", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.PrefetchUsers) },
+		set: func(c *Config, v string) error { c.PrefetchUsers = v == "true"; return nil }},
+	{key: "userRefreshMinutes", kind: settingNumber,
+		get: func(c Config) string { return strconv.Itoa(c.UserRefreshMinutes) },
+		set: func(c *Config, v string) error { return parseSettingInt(v, 0, &c.UserRefreshMinutes) }},
+	{key: "rateLimit", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.RateLimit) },
+		set: func(c *Config, v string) error { c.RateLimit = v == "true"; return nil }},
+	{key: "timezone", kind: settingText,
+		get: func(c Config) string { return c.Timezone },
+		set: func(c *Config, v string) error {
+			if v != "" {
+				if _, err := time.LoadLocation(v); err != nil {
+					return fmt.Errorf(tr("settings.unknown_timezone"), v)
+				}
+			}
+			c.Timezone = v
+			return nil
+		}},
+	{key: "quickNote.channel", kind: settingText,
+		get: func(c Config) string { return c.QuickNote.Channel },
+		set: func(c *Config, v string) error { c.QuickNote.Channel = v; return nil }},
+	{key: "quickNote.quitAfterSend", kind: settingBool,
+		get: func(c Config) string { return strconv.FormatBool(c.QuickNote.QuitAfterSend) },
+		set: func(c *Config, v string) error { c.QuickNote.QuitAfterSend = v == "true"; return nil }},
+	{key: "persona.username", kind: settingText,
+		get: func(c Config) string { return c.Persona.Username },
+		set: func(c *Config, v string) error { c.Persona.Username = strings.TrimSpace(v); return nil }},
+	{key: "persona.iconEmoji", kind: settingText,
+		get: func(c Config) string { return c.Persona.IconEmoji },
+		set: func(c *Config, v string) error { return parseSettingEmoji(v, &c.Persona.IconEmoji) }},
+	{key: "keys.cycleStatus", kind: settingText,
+		get: func(c Config) string { return c.Keys.CycleStatus },
+		set: func(c *Config, v string) error { return parseSettingKey(v, &c.Keys.CycleStatus) }},
+	{key: "keys.toggleSchedule", kind: settingText,
+		get: func(c Config) string { return c.Keys.ToggleSchedule },
+		set: func(c *Config, v string) error { return parseSettingKey(v, &c.Keys.ToggleSchedule) }},
+	{key: "keys.reloadConfig", kind: settingText,
+		get: func(c Config) string { return c.Keys.ReloadConfig },
+		set: func(c *Config, v string) error { return parseSettingKey(v, &c.Keys.ReloadConfig) }},
+	{key: "keys.refreshUsers", kind: settingText,
+		get: func(c Config) string { return c.Keys.RefreshUsers },
+		set: func(c *Config, v string) error { return parseSettingKey(v, &c.Keys.RefreshUsers) }},
+	{key: "keys.focusMode", kind: settingText,
+		get: func(c Config) string { return c.Keys.FocusMode },
+		set: func(c *Config, v string) error { return parseSettingKey(v, &c.Keys.FocusMode) }},
+	{key: "keys.sendMessage", kind: settingText,
+		get: func(c Config) string { return c.Keys.SendMessage },
+		set: func(c *Config, v string) error {
+			if strings.TrimSpace(v) == c.Keys.NewLine {
+				return errors.New(tr("settings.already_newline"))
+			}
+			return parseSettingKey(v, &c.Keys.SendMessage)
+		}},
+	{key: "keys.newLine", kind: settingText,
+		get: func(c Config) string { return c.Keys.NewLine },
+		set: func(c *Config, v string) error {
+			if strings.TrimSpace(v) == c.Keys.SendMessage {
+				return errors.New(tr("settings.already_send"))
+			}
+			return parseSettingKey(v, &c.Keys.NewLine)
+		}},
+	{key: "theme.appBorder", kind: settingChoice, options: borderNames,
+		get: func(c Config) string { return c.Theme.AppBorder },
+		set: func(c *Config, v string) error { c.Theme.AppBorder = v; return nil }},
+	{key: "theme.viewportBorder", kind: settingChoice, options: borderNames,
+		get: func(c Config) string { return c.Theme.ViewportBorder },
+		set: func(c *Config, v string) error { c.Theme.ViewportBorder = v; return nil }},
+	{key: "theme.mode", kind: settingChoice, options: themeModes,
+		get: func(c Config) string { return c.Theme.Mode },
+		set: func(c *Config, v string) error { c.Theme.Mode = v; return nil }},
+	{key: "theme.selectedChannel", kind: settingText,
+		get: func(c Config) string { return c.Theme.SelectedChannel },
+		set: func(c *Config, v string) error {
+			v = strings.TrimSpace(v)
+			if v != "" && !colorPattern.MatchString(v) {
+				return fmt.Errorf(tr("settings.bad_color"), v)
+			}
+			c.Theme.SelectedChannel = v
+			return nil
+		}},
+}
+
+// Border styles in the order the settings page cycles through them
+var borderNames = []string{"rounded", "normal", "thick", "double", "none"}
+
+// Parse a whole number no smaller than min
+func parseSettingInt(value string, min int, dest *int) error {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf(tr("settings.not_number"), value)
+	}
+	if n < min {
+		return fmt.Errorf(tr("settings.too_small"), min)
+	}
+	*dest = n
+	return nil
+}
+
+// Accept a key binding as bubbletea names it, e.g. "S" or "ctrl+s"
+func parseSettingKey(value string, dest *string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.New(tr("settings.key_required"))
+	}
+	*dest = value
+	return nil
+}
+
+// Accept an emoji as Slack writes it, e.g. ":robot_face:", or nothing
+func parseSettingEmoji(value string, dest *string) error {
+	value = strings.TrimSpace(value)
+	if value != "" && (len(value) < 3 || !strings.HasPrefix(value, ":") || !strings.HasSuffix(value, ":")) {
+		return fmt.Errorf(tr("settings.bad_emoji"), value)
+	}
+	*dest = value
+	return nil
+}
+
+// SettingItem represents a setting and its current value in the settings list
+type SettingItem struct {
+	setting setting
+	value   string
+}
+
+// Implement the list.Item interface
+func (s SettingItem) Title() string { return s.setting.key }
+func (s SettingItem) Description() string {
+	if s.value == "" {
+		return tr("settings.unset")
+	}
+	return s.value
+}
+func (s SettingItem) FilterValue() string { return s.setting.key }
+
+// Open the settings page
+func (m *Model) openSettings() tea.Cmd {
+	m.editingSetting = ""
+	m.settingError = ""
+	m.currentPage = pageSettings
+	return m.refreshSettings()
+}
+
+// Rebuild the settings list from the current config
+func (m *Model) refreshSettings() tea.Cmd {
+	items := make([]list.Item, len(settings))
+	for i, s := range settings {
+		items[i] = SettingItem{setting: s, value: s.get(m.config)}
+	}
+	return m.settingsList.SetItems(items)
+}
+
+// Handle input on the settings page: toggling, cycling, or editing the selected setting
+func (m *Model) updateSettings(msg tea.Msg) tea.Cmd {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+
+	// Inline editing of a text setting
+	if m.editingSetting != "" {
+		if isKey {
+			switch keyMsg.String() {
+			case "esc":
+				m.editingSetting = ""
+				m.settingError = ""
+				return nil
+			case "enter":
+				for _, s := range settings {
+					if s.key == m.editingSetting {
+						if m.saveSetting(s, m.settingInput.Value()) {
+							m.editingSetting = ""
+						}
+					}
+				}
+				return m.refreshSettings()
+			}
+		}
+
+		var cmd tea.Cmd
+		m.settingInput, cmd = m.settingInput.Update(msg)
+		return cmd
+	}
+
+	if isKey && keyMsg.String() == "enter" && m.settingsList.FilterState() != list.Filtering {
+		item, ok := m.settingsList.SelectedItem().(SettingItem)
+		if !ok {
+			return nil
+		}
+
+		switch item.setting.kind {
+		case settingBool:
+			m.saveSetting(item.setting, strconv.FormatBool(item.value != "true"))
+		case settingChoice:
+			m.saveSetting(item.setting, nextOption(item.setting.options, item.value))
+		case settingText, settingNumber:
+			m.editingSetting = item.setting.key
+			m.settingError = ""
+			m.settingInput.SetValue(item.value)
+			m.settingInput.CursorEnd()
+			m.settingInput.Focus()
+		}
+		return m.refreshSettings()
+	}
+
+	var cmd tea.Cmd
+	m.settingsList, cmd = m.settingsList.Update(msg)
+	return cmd
+}
+
+// The option after the current one, wrapping around
+func nextOption(options []string, current string) string {
+	for i, option := range options {
+		if option == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+// Validate and apply a new value, then write it to the config file. Returns
+// false and shows the problem inline if the value can't be used.
+func (m *Model) saveSetting(s setting, value string) bool {
+	cfg := m.config
+	if err := s.set(&cfg, value); err != nil {
+		m.settingError = fmt.Sprintf("%s: %v", s.key, err)
+		return false
+	}
+	if err := writeConfigValue(s.key, s.get(cfg), s.kind); err != nil {
+		m.settingError = fmt.Sprintf(tr("settings.save_failed"), err)
+		return false
+	}
+
+	m.config = cfg
+	m.settingError = ""
+
+	// Apply what can change without a restart, redrawing the menus in a new language
+	setLocale(cfg.Locale)
+	m.applyLocale()
+	m.quickActions.SetItems(quickActionItems(cfg))
+	m.statusOptions.SetItems(statusItems(cfg))
+	m.newestFirst = cfg.NewestFirst
+	m.channelSort = cfg.ChannelSort
+	m.composeInput.KeyMap.InsertNewline.SetKeys(cfg.Keys.NewLine)
+	m.viewport.MouseWheelDelta = wheelLines(cfg)
+	applyTheme(cfg.Theme)
+	m.viewport.Style = m.viewport.Style.BorderStyle(borderStyles[cfg.Theme.ViewportBorder]).BorderForeground(primaryColor)
+	m.spinner.Style = m.spinner.Style.Foreground(primaryColor)
+	m.applyListDelegates()
+	m.applyLayout()
+	return true
+}
+
+// Set one value in the config file. The file is read and written back whole, so
+// every other value is kept but its keys come out sorted and re-indented.
+func writeConfigValue(key, value, kind string) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	raw := make(map[string]any)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	// Store numbers and booleans as JSON values rather than strings
+	var v any = value
+	switch kind {
+	case settingBool:
+		v = value == "true"
+	case settingNumber:
+		if n, err := strconv.Atoi(value); err == nil {
+			v = n
+		}
+	}
+
+	// Walk down dotted keys like "theme.appBorder", creating objects as needed
+	parts := strings.Split(key, ".")
+	obj := raw
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := obj[part].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			obj[part] = child
+		}
+		obj = child
+	}
+	obj[parts[len(parts)-1]] = v
+
+	out, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, out)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveSettingLocaleAppliesAtOnce(t *testing.T) {
+	t.Cleanup(func() { setLocale(defaultConfig().Locale) })
+	writeTestConfig(t, `{"confirmQuit": true}`)
+
+	m := newTestModel(&fakeSlack{})
+	for _, s := range settings {
+		if s.key == "locale" && !m.saveSetting(s, "es") {
+			t.Fatalf("saving the locale failed: %s", m.settingError)
+		}
+	}
+
+	first, _ := m.quickActions.Items()[0].(QuickAction)
+	if want := "1. " + tr("menu.view_messages"); first.name != want || want == "1. View Messages" {
+		t.Errorf("first quick action = %q, want %q in Spanish", first.name, want)
+	}
+	if m.quickActions.Title != tr("menu.quick_actions") {
+		t.Errorf("menu title = %q, want %q", m.quickActions.Title, tr("menu.quick_actions"))
+	}
+
+	// The rest of the file is kept
+	data, err := os.ReadFile(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "lazyslackui", "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved map[string]any
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("the saved config isn't JSON: %v", err)
+	}
+	if saved["locale"] != "es" || saved["confirmQuit"] != true {
+		t.Errorf("saved config = %v, want locale es and confirmQuit kept", saved)
+	}
+}
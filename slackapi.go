@@ -0,0 +1,41 @@
+package main
+
+import (
+	"io"
+
+	"github.com/slack-go/slack"
+)
+
+// SlackAPI is the part of the Slack Web API the app uses. *slack.Client
+// implements it; anything else that does can stand in for it.
+type SlackAPI interface {
+	// Conversations and their history
+	GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+	GetConversationInfo(input *slack.GetConversationInfoInput) (*slack.Channel, error)
+	GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	GetConversationReplies(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error)
+	GetUsersInConversation(params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	MarkConversation(channel, ts string) error
+
+	// Messages, reactions, and pins
+	PostMessage(channelID string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessage(channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+	DeleteMessage(channel, messageTimestamp string) (string, string, error)
+	GetPermalink(params *slack.PermalinkParameters) (string, error)
+	AddReaction(name string, item slack.ItemRef) error
+	RemoveReaction(name string, item slack.ItemRef) error
+	AddPin(channel string, item slack.ItemRef) error
+	ListPins(channel string) ([]slack.Item, *slack.Paging, error)
+	GetFile(downloadURL string, writer io.Writer) error
+
+	// Users, presence, and status
+	GetUserInfo(user string) (*slack.User, error)
+	GetUsersInfo(users ...string) (*[]slack.User, error)
+	GetUsers(options ...slack.GetUsersOption) ([]slack.User, error)
+	GetUsersPaginated(options ...slack.GetUsersOption) slack.UserPagination
+	GetUserPresence(user string) (*slack.UserPresence, error)
+	SetUserPresence(presence string) error
+	SetUserCustomStatus(statusText, statusEmoji string, statusExpiration int64) error
+}
+
+var _ SlackAPI = (*slack.Client)(nil)
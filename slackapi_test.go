@@ -0,0 +1,367 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// A stand-in for the Slack Web API that serves canned responses and records
+// the calls made. Methods a test doesn't need panic on the nil SlackAPI.
+type fakeSlack struct {
+	SlackAPI
+
+	mu    sync.Mutex
+	calls []string
+
+	// Pages of conversations.list, in order, and the page that fails instead
+	conversations    [][]slack.Channel
+	conversationsErr map[int]error
+
+	// Each channel's history, newest first as Slack returns it, and the cursor
+	// to its older page in olderHistory
+	history      map[string][]slack.Message
+	historyErr   map[string]error
+	historyNext  map[string]string
+	olderHistory map[string][]slack.Message
+
+	// The parameters of each history request, in order
+	historyParams []slack.GetConversationHistoryParameters
+
+	// conversations.info by channel ID
+	info map[string]*slack.Channel
+
+	// Threads by the timestamp of their parent message, parent first
+	replies map[string][]slack.Message
+
+	// Display names by user ID. Lookups are counted rather than recorded as
+	// calls, so tests of other calls needn't list them.
+	users       map[string]string
+	userLookups int
+
+	presenceErr error
+	statusErr   error
+	postErr     error
+	markErr     error
+}
+
+func (f *fakeSlack) record(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fmt.Sprintf(format, args...))
+}
+
+// The calls made so far, e.g. `SetUserPresence("auto")`
+func (f *fakeSlack) called() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeSlack) GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	page := 0
+	if params.Cursor != "" {
+		fmt.Sscanf(params.Cursor, "page%d", &page)
+	}
+	f.record("GetConversations(%q)", params.Cursor)
+
+	if err := f.conversationsErr[page]; err != nil {
+		return nil, "", err
+	}
+	if page >= len(f.conversations) {
+		return nil, "", nil
+	}
+	next := ""
+	if page+1 < len(f.conversations) {
+		next = fmt.Sprintf("page%d", page+1)
+	}
+	return f.conversations[page], next, nil
+}
+
+func (f *fakeSlack) GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	f.mu.Lock()
+	f.historyParams = append(f.historyParams, *params)
+	f.mu.Unlock()
+
+	if params.Cursor != "" {
+		f.record("GetConversationHistory(%q, %q)", params.ChannelID, params.Cursor)
+		return &slack.GetConversationHistoryResponse{Messages: f.olderHistory[params.Cursor]}, nil
+	}
+
+	f.record("GetConversationHistory(%q)", params.ChannelID)
+	if err := f.historyErr[params.ChannelID]; err != nil {
+		return nil, err
+	}
+	response := &slack.GetConversationHistoryResponse{Messages: f.history[params.ChannelID]}
+	response.ResponseMetaData.NextCursor = f.historyNext[params.ChannelID]
+	return response, nil
+}
+
+func (f *fakeSlack) GetConversationInfo(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	f.record("GetConversationInfo(%q)", input.ChannelID)
+	info, ok := f.info[input.ChannelID]
+	if !ok {
+		return nil, errors.New("channel_not_found")
+	}
+	return info, nil
+}
+
+func (f *fakeSlack) GetConversationReplies(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	f.record("GetConversationReplies(%q, %q)", params.ChannelID, params.Timestamp)
+	return f.replies[params.Timestamp], false, "", nil
+}
+
+func (f *fakeSlack) GetUserInfo(user string) (*slack.User, error) {
+	f.mu.Lock()
+	f.userLookups++
+	f.mu.Unlock()
+
+	name, ok := f.users[user]
+	if !ok {
+		return nil, errors.New("user_not_found")
+	}
+	return &slack.User{ID: user, Name: name}, nil
+}
+
+func (f *fakeSlack) GetUsersInfo(users ...string) (*[]slack.User, error) {
+	f.record("GetUsersInfo(%q)", users)
+	var found []slack.User
+	for _, user := range users {
+		if name, ok := f.users[user]; ok {
+			found = append(found, slack.User{ID: user, Name: name})
+		}
+	}
+	return &found, nil
+}
+
+func (f *fakeSlack) PostMessage(channelID string, options ...slack.MsgOption) (string, string, error) {
+	_, values, _ := slack.UnsafeApplyMsgOptions("", channelID, "", options...)
+	f.record("PostMessage(%q, %q)", channelID, values.Get("text"))
+	if f.postErr != nil {
+		return "", "", f.postErr
+	}
+	return channelID, "1700000000.000100", nil
+}
+
+func (f *fakeSlack) AddReaction(name string, item slack.ItemRef) error {
+	f.record("AddReaction(%q, %q, %q)", name, item.Channel, item.Timestamp)
+	return nil
+}
+
+func (f *fakeSlack) SetUserPresence(presence string) error {
+	f.record("SetUserPresence(%q)", presence)
+	return f.presenceErr
+}
+
+func (f *fakeSlack) SetUserCustomStatus(statusText, statusEmoji string, statusExpiration int64) error {
+	f.record("SetUserCustomStatus(%q, %q, %d)", statusText, statusEmoji, statusExpiration)
+	return f.statusErr
+}
+
+func (f *fakeSlack) MarkConversation(channel, ts string) error {
+	f.record("MarkConversation(%q, %q)", channel, ts)
+	return f.markErr
+}
+
+// A model with the default config talking to a fake Slack, sized like a typical terminal
+func newTestModel(client *fakeSlack) Model {
+	m := initialModel(defaultConfig(), State{LastSeen: make(map[string]string), Drafts: make(map[string]string), LastChannels: make(map[string]string)})
+	m.slackClient = client
+	m.isLoading = false
+	m.channels = []slack.Channel{
+		testChannel("C1", "general"),
+		testChannel("C2", "random"),
+		testChannel("C3", "dev"),
+	}
+	updated, _ := m.handleMsg(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return updated.(Model)
+}
+
+func testChannel(id, name string) slack.Channel {
+	var ch slack.Channel
+	ch.ID = id
+	ch.Name = name
+	return ch
+}
+
+func testMessage(user, text, ts string) slack.Message {
+	return slack.Message{Msg: slack.Msg{User: user, Text: text, Timestamp: ts}}
+}
+
+// Run a command and the commands of any batch it makes, returning the messages they produce
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	var msgs []tea.Msg
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			msgs = append(msgs, runCmd(c)...)
+		}
+	case nil:
+	default:
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func equalCalls(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("calls = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("calls = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestLoadMessagesSelectedChannel(t *testing.T) {
+	client := &fakeSlack{
+		users: map[string]string{"U1": "alice"},
+		history: map[string][]slack.Message{
+			"C2": {testMessage("U1", "newer", "1700000002.000000"), testMessage("U1", "older", "1700000001.000000")},
+		},
+	}
+	m := newTestModel(client)
+	m.selectedChannelID = "C2"
+
+	msg, ok := m.loadMessages().(messagesMsg)
+	if !ok {
+		t.Fatalf("loadMessages returned %T, want messagesMsg", m.loadMessages())
+	}
+	if msg.channelID != "C2" {
+		t.Errorf("channelID = %q, want C2", msg.channelID)
+	}
+	if len(msg.messages) != 2 || msg.messages[0].Content != "older" || msg.messages[1].Content != "newer" {
+		t.Errorf("messages = %+v, want older then newer", msg.messages)
+	} else if msg.messages[0].User != "alice" {
+		t.Errorf("author = %q, want alice", msg.messages[0].User)
+	}
+	equalCalls(t, client.called(), []string{`GetConversationHistory("C2")`})
+}
+
+func TestLoadMessagesError(t *testing.T) {
+	client := &fakeSlack{historyErr: map[string]error{"C1": errors.New("boom")}}
+	m := newTestModel(client)
+	m.selectedChannelID = "C1"
+
+	if _, ok := m.loadMessages().(genericErrMsg); !ok {
+		t.Errorf("loadMessages returned %T, want genericErrMsg", m.loadMessages())
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+
+	status := StatusOption{Name: "Lunch", Presence: statusAway, StatusText: "At lunch", Emoji: ":pizza:"}
+	msg, ok := m.setStatus(status, 1700000000).(statusUpdatedMsg)
+	if !ok {
+		t.Fatalf("setStatus didn't report an update")
+	}
+	if msg.label != "Lunch" || msg.expiration != 1700000000 {
+		t.Errorf("statusUpdatedMsg = %+v", msg)
+	}
+	equalCalls(t, client.called(), []string{
+		`SetUserPresence("away")`,
+		`SetUserCustomStatus("At lunch", ":pizza:", 1700000000)`,
+	})
+}
+
+func TestSetStatusPresenceError(t *testing.T) {
+	client := &fakeSlack{presenceErr: errors.New("boom")}
+	m := newTestModel(client)
+
+	if _, ok := m.setStatus(StatusOption{Presence: statusAway}, 0).(genericErrMsg); !ok {
+		t.Fatalf("setStatus didn't report the error")
+	}
+	// The custom status isn't touched once the presence fails
+	equalCalls(t, client.called(), []string{`SetUserPresence("away")`})
+}
+
+func TestSendPresetMessage(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.selectedChannelID = "C3"
+
+	msg, ok := m.sendPresetMessage("Back in 5").(messageSentMsg)
+	if !ok {
+		t.Fatalf("sendPresetMessage didn't report a sent message")
+	}
+	if msg.channelID != "C3" || msg.text != "Back in 5" || msg.timestamp == "" {
+		t.Errorf("messageSentMsg = %+v", msg)
+	}
+	equalCalls(t, client.called(), []string{`PostMessage("C3", "Back in 5")`})
+}
+
+func TestSendPresetMessageWithoutChannel(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+
+	if _, ok := m.sendPresetMessage("Back in 5").(genericErrMsg); !ok {
+		t.Errorf("sendPresetMessage without a channel should fail")
+	}
+	equalCalls(t, client.called(), nil)
+}
+
+func TestMessageSentBanner(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.currentPage = pagePresetMessage
+
+	updated, _ := m.handleMsg(messageSentMsg{channelID: "C2", timestamp: "1700000000.000100", text: "Back in 5"})
+	m = updated.(Model)
+
+	sent := parseSlackTimestamp("1700000000.000100").In(m.configuredLocation()).Format("15:04")
+	if want := fmt.Sprintf(tr("toast.sent_at"), "random", sent); m.toast != want || m.toastIsError {
+		t.Errorf("toast = %q (error %v), want %q", m.toast, m.toastIsError, want)
+	}
+
+	// Without a timestamp to show, it still names the channel
+	updated, _ = m.handleMsg(messageSentMsg{channelID: "C2", text: "Back in 5"})
+	if want := fmt.Sprintf(tr("toast.sent_to"), "random"); updated.(Model).toast != want {
+		t.Errorf("toast = %q, want %q", updated.(Model).toast, want)
+	}
+}
+
+func TestFetchMessagesLimits(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.config.SingleChannelLimit = 25
+	m.config.AllChannelsLimit = 7
+	m.config.HistoryHours = 2
+
+	m.selectedChannelID = "C2"
+	oldest := time.Now().Add(-2 * time.Hour)
+	m.fetchMessages()
+	if len(client.historyParams) != 1 {
+		t.Fatalf("made %d history requests, want 1", len(client.historyParams))
+	}
+	params := client.historyParams[0]
+	if params.Limit != 25 {
+		t.Errorf("limit = %d, want the single channel limit of 25", params.Limit)
+	}
+	if since := parseSlackTimestamp(params.Oldest).Sub(oldest); since < -time.Second || since > time.Second {
+		t.Errorf("oldest = %s, want two hours ago", params.Oldest)
+	}
+
+	// The combined view asks each channel for the all channels limit
+	client.historyParams = nil
+	m.selectedChannelID = ""
+	m.fetchMessages()
+	if len(client.historyParams) == 0 {
+		t.Fatal("the combined view made no history requests")
+	}
+	for _, params := range client.historyParams {
+		if params.Limit != 7 {
+			t.Errorf("%s limit = %d, want the all channels limit of 7", params.ChannelID, params.Limit)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// State holds what the app remembers between sessions
+type State struct {
+	// Timestamp of the newest message seen in each channel, keyed by channel ID
+	LastSeen map[string]string `json:"lastSeen"`
+
+	// Messages page layout last used, "single" or "split", and the channel pane's share of the width
+	Layout     string  `json:"layout"`
+	SplitRatio float64 `json:"splitRatio"`
+
+	// Unsent compose text, keyed by channel ID, or channel ID and thread timestamp for replies
+	Drafts map[string]string `json:"drafts,omitempty"`
+
+	// Channel last shown in each workspace, keyed by its configured name ("" for the default token's)
+	LastChannels map[string]string `json:"lastChannels,omitempty"`
+
+	// Page the last session quit on, when it was one to return to: the messages page or the main menu
+	LastPage string `json:"lastPage,omitempty"`
+}
+
+// Path to the state file, kept next to the config file
+func statePath() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "state.json"), nil
+}
+
+// Load the saved state, starting fresh when there isn't any
+func loadState() (State, error) {
+	state := State{LastSeen: make(map[string]string), Drafts: make(map[string]string), LastChannels: make(map[string]string)}
+
+	path, err := statePath()
+	if err != nil {
+		return state, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{LastSeen: make(map[string]string), Drafts: make(map[string]string), LastChannels: make(map[string]string)}, err
+	}
+	if state.LastSeen == nil {
+		state.LastSeen = make(map[string]string)
+	}
+	if state.Drafts == nil {
+		state.Drafts = make(map[string]string)
+	}
+	if state.LastChannels == nil {
+		state.LastChannels = make(map[string]string)
+	}
+
+	return state, nil
+}
+
+// Save the state, replacing the file atomically
+func saveState(state State) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// Write a file by renaming a finished temp file over it, so a crash can't leave it half-written
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// Nothing saved yet starts fresh
+	fresh, err := loadState()
+	if err != nil {
+		t.Fatalf("loadState: %v", err)
+	}
+	if fresh.LastChannels == nil || len(fresh.LastChannels) != 0 {
+		t.Errorf("fresh LastChannels = %v, want empty", fresh.LastChannels)
+	}
+
+	saved := State{
+		LastSeen:     map[string]string{"C1": "1700000001.000000"},
+		Layout:       "split",
+		SplitRatio:   0.3,
+		Drafts:       map[string]string{"C2": "half a thought"},
+		LastChannels: map[string]string{"": "C2", "beta": "D1"},
+		LastPage:     pageMessages,
+	}
+	if err := saveState(saved); err != nil {
+		t.Fatalf("saveState: %v", err)
+	}
+
+	loaded, err := loadState()
+	if err != nil {
+		t.Fatalf("loadState: %v", err)
+	}
+	if !maps.Equal(loaded.LastChannels, saved.LastChannels) || !maps.Equal(loaded.LastSeen, saved.LastSeen) || !maps.Equal(loaded.Drafts, saved.Drafts) {
+		t.Errorf("loaded %+v, want %+v", loaded, saved)
+	}
+	if loaded.LastPage != pageMessages {
+		t.Errorf("LastPage = %q, want %q", loaded.LastPage, pageMessages)
+	}
+	if loaded.Layout != saved.Layout || loaded.SplitRatio != saved.SplitRatio {
+		t.Errorf("layout = %q at %v, want %q at %v", loaded.Layout, loaded.SplitRatio, saved.Layout, saved.SplitRatio)
+	}
+}
+
+func TestLastChannelRestored(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.state.LastChannels[""] = "C3"
+	if got := m.lastChannel(); got != "C3" {
+		t.Errorf("lastChannel() = %q, want C3", got)
+	}
+
+	// A channel that's gone since is forgotten
+	m.state.LastChannels[""] = "C9"
+	if got := m.lastChannel(); got != "" {
+		t.Errorf("lastChannel() = %q for a missing channel, want none", got)
+	}
+	if _, ok := m.state.LastChannels[""]; ok {
+		t.Error("the missing channel wasn't forgotten")
+	}
+}
+
+func TestResumablePage(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	for page, want := range map[string]string{
+		pageMessages:    pageMessages,
+		pageThread:      pageMessages,
+		pageMain:        pageMain,
+		pageCompose:     pageMain,
+		pageChannelList: pageMain,
+	} {
+		m.currentPage = page
+		if got := m.resumablePage(); got != want {
+			t.Errorf("resumablePage() on %q = %q, want %q", page, got, want)
+		}
+	}
+}
+
+func TestLastPageRestored(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.state.LastChannels[""] = "C2"
+	m.state.LastPage = pageMessages
+
+	updated, _ := m.handleMsg(initMsg{workspace: m.workspace, client: &fakeSlack{}, channels: m.channels})
+	m = updated.(Model)
+	if m.currentPage != pageMessages || m.selectedChannelID != "C2" {
+		t.Errorf("page = %q in %q, want #random's messages", m.currentPage, m.selectedChannelID)
+	}
+
+	// A quick note starts in compose whatever page the last session quit on
+	m = newTestModel(&fakeSlack{})
+	m.state.LastPage = pageMessages
+	m.config.QuickNote.Channel = "general"
+	updated, _ = m.handleMsg(initMsg{workspace: m.workspace, client: &fakeSlack{}, channels: m.channels})
+	if page := updated.(Model).currentPage; page != pageCompose {
+		t.Errorf("page = %q with a quick note, want compose", page)
+	}
+}
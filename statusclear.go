@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// When a status set from the status page clears itself
+const (
+	clearIn30Minutes = "30m"
+	clearIn1Hour     = "1h"
+	clearIn4Hours    = "4h"
+	clearToday       = "today"
+	clearNever       = "never"
+)
+
+// The Set Status entry that clears the custom status instead of setting one
+const statusClearID = "clear"
+
+// The choices of when to clear a status, in the order offered
+func statusClearItems() []list.Item {
+	return []list.Item{
+		QuickAction{id: clearIn30Minutes, name: tr("statusclear.30m")},
+		QuickAction{id: clearIn1Hour, name: tr("statusclear.1h")},
+		QuickAction{id: clearIn4Hours, name: tr("statusclear.4h")},
+		QuickAction{id: clearToday, name: tr("statusclear.today")},
+		QuickAction{id: clearNever, name: tr("statusclear.never")},
+	}
+}
+
+// The Unix time a status should clear at for a choice, 0 for never
+func statusExpiration(choice string, now time.Time) int64 {
+	switch choice {
+	case clearIn30Minutes:
+		return now.Add(30 * time.Minute).Unix()
+	case clearIn1Hour:
+		return now.Add(time.Hour).Unix()
+	case clearIn4Hours:
+		return now.Add(4 * time.Hour).Unix()
+	case clearToday:
+		return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location()).Unix()
+	}
+	return 0
+}
+
+// Ask when the chosen status should clear before setting it
+func (m *Model) openStatusClear(status StatusOption) {
+	m.pendingStatus = status
+	m.statusClearOptions.Select(0)
+	m.currentPage = pageStatusClear
+}
+
+// Set the pending status to clear at the chosen time
+func (m *Model) confirmStatusClear(choice string) tea.Cmd {
+	status := m.pendingStatus
+	expiration := statusExpiration(choice, time.Now().In(m.configuredLocation()))
+	m.isLoading = true
+	return func() tea.Msg {
+		return m.setStatus(status, expiration)
+	}
+}
+
+// Clear the custom status and hand presence back to Slack's automatic tracking
+func (m *Model) clearStatus() tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	err := m.slackClient.SetUserCustomStatus("", "", 0)
+	if err != nil {
+		return classifyError(tr("error.clear_status"), err, m.clearStatus)
+	}
+
+	presence, _ := slackPresence(statusActive)
+	err = m.slackClient.SetUserPresence(presence)
+	if err != nil {
+		return classifyError(tr("error.set_presence"), err, m.clearStatus)
+	}
+
+	return statusUpdatedMsg{status: statusCleared, cleared: true}
+}
+
+// The toast confirming a status change, with when it clears
+func (m Model) statusSetText(msg statusUpdatedMsg) string {
+	if msg.cleared {
+		return tr("toast.status_cleared")
+	}
+	text := fmt.Sprintf(tr("toast.status_set"), msg.label)
+	if msg.expiration == 0 {
+		return text
+	}
+
+	clears := time.Unix(msg.expiration, 0).In(m.configuredLocation())
+	format := "15:04"
+	if !sameDay(clears, time.Now().In(clears.Location())) {
+		format = "Mon 15:04"
+	}
+	return text + ", " + fmt.Sprintf(tr("toast.status_clears"), clears.Format(format))
+}
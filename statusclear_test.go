@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestClearStatus(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.currentPage = pageSetStatus
+	m.statusOptions.Select(len(m.statusOptions.Items()) - 1)
+
+	updated, cmd := m.handleMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	for _, msg := range runCmd(cmd) {
+		updated, _ = m.handleMsg(msg)
+		m = updated.(Model)
+	}
+
+	equalCalls(t, client.called(), []string{`SetUserCustomStatus("", "", 0)`, `SetUserPresence("auto")`})
+	if m.userStatus != statusCleared {
+		t.Errorf("userStatus = %q, want %q", m.userStatus, statusCleared)
+	}
+	if view := m.View(); !strings.Contains(view, tr("status.cleared")) || strings.Contains(view, tr("status.active")) {
+		t.Errorf("header doesn't show the cleared status:\n%s", view)
+	}
+}
+
+func TestStatusClearInOneHour(t *testing.T) {
+	client := &fakeSlack{}
+	m := newTestModel(client)
+	m.openStatusClear(StatusOption{Name: "Meeting", Presence: statusActive, StatusText: "In a meeting", Emoji: ":calendar:"})
+
+	before := time.Now().Unix()
+	msgs := runCmd(m.confirmStatusClear(clearIn1Hour))
+	after := time.Now().Unix()
+
+	if len(msgs) != 1 {
+		t.Fatalf("confirmStatusClear produced %d messages, want 1", len(msgs))
+	}
+	msg, ok := msgs[0].(statusUpdatedMsg)
+	if !ok {
+		t.Fatalf("confirmStatusClear produced %T, want statusUpdatedMsg", msgs[0])
+	}
+	if msg.expiration < before+3600 || msg.expiration > after+3600 {
+		t.Errorf("expiration = %d, want within [%d, %d]", msg.expiration, before+3600, after+3600)
+	}
+	equalCalls(t, client.called(), []string{
+		`SetUserPresence("auto")`,
+		fmt.Sprintf(`SetUserCustomStatus("In a meeting", ":calendar:", %d)`, msg.expiration),
+	})
+}
@@ -0,0 +1,41 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+// Which background the colors are picked for
+const (
+	themeAuto  = "auto"
+	themeLight = "light"
+	themeDark  = "dark"
+)
+
+var themeModes = []string{themeAuto, themeLight, themeDark}
+
+// A color's defaults for light and dark terminal backgrounds
+type paletteColor struct {
+	light string
+	dark  string
+}
+
+var (
+	paletteSelectedChannel = paletteColor{light: "#9A6700", dark: "#FFD966"}
+	palettePrimary         = paletteColor{light: "#3A5F98", dark: "#6C8EBF"}
+	paletteSecondary       = paletteColor{light: "#4A5568", dark: "#DAE8FC"}
+	paletteAccent          = paletteColor{light: "#2F6B2F", dark: "#D5E8D4"}
+	paletteError           = paletteColor{light: "#B3261E", dark: "#F8CECC"}
+)
+
+// The color to use: the configured one, or else the palette's for the mode,
+// which in auto mode follows the terminal's background
+func themeColor(configured string, palette paletteColor, mode string) lipgloss.TerminalColor {
+	if configured != "" {
+		return lipgloss.Color(configured)
+	}
+	switch mode {
+	case themeLight:
+		return lipgloss.Color(palette.light)
+	case themeDark:
+		return lipgloss.Color(palette.dark)
+	}
+	return lipgloss.AdaptiveColor{Light: palette.light, Dark: palette.dark}
+}
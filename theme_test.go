@@ -0,0 +1,61 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestThemePrimaryColor(t *testing.T) {
+	t.Cleanup(func() { applyTheme(defaultConfig().Theme) })
+	writeTestConfig(t, `{"theme": {"primary": "#FF00FF"}}`)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	applyTheme(cfg.Theme)
+
+	want := lipgloss.Color("#FF00FF")
+	if got := titleStyle.GetForeground(); got != want {
+		t.Errorf("title color = %v, want %v", got, want)
+	}
+	if got := menuStyle.GetBorderTopForeground(); got != want {
+		t.Errorf("menu border color = %v, want %v", got, want)
+	}
+}
+
+func TestThemeColorAdaptive(t *testing.T) {
+	color := themeColor("", palettePrimary, themeAuto)
+	if _, ok := color.(lipgloss.AdaptiveColor); !ok {
+		t.Fatalf("auto mode color = %T, want an adaptive color", color)
+	}
+
+	// Render on each background with colors on, as a true color terminal would
+	render := func(dark bool) string {
+		r := lipgloss.NewRenderer(io.Discard)
+		r.SetColorProfile(termenv.TrueColor)
+		r.SetHasDarkBackground(dark)
+		return r.NewStyle().Foreground(color).Render("x")
+	}
+	if light, dark := render(false), render(true); light == dark {
+		t.Errorf("the adaptive color renders as %q on both light and dark backgrounds", light)
+	}
+
+	// A fixed mode or a configured color doesn't adapt
+	tests := []struct {
+		configured, mode string
+		want             lipgloss.TerminalColor
+	}{
+		{"", themeLight, lipgloss.Color(palettePrimary.light)},
+		{"", themeDark, lipgloss.Color(palettePrimary.dark)},
+		{"#FF00FF", themeAuto, lipgloss.Color("#FF00FF")},
+	}
+	for _, tt := range tests {
+		if got := themeColor(tt.configured, palettePrimary, tt.mode); got != tt.want {
+			t.Errorf("themeColor(%q, %q) = %v, want %v", tt.configured, tt.mode, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// A request took longer than timeoutSeconds; retry re-issues it when the user presses r
+type timeoutErrMsg struct {
+	appError
+	retry tea.Cmd
+}
+
+// Run a request, giving up on it once timeoutSeconds have passed. A result
+// that arrives after that is dropped, closing any live connection it opened.
+func (m *Model) awaitTimeout(text string, request, retry tea.Cmd) tea.Msg {
+	if m.config.TimeoutSeconds <= 0 {
+		return request()
+	}
+
+	result := make(chan tea.Msg, 1)
+	go func() { result <- request() }()
+
+	timer := time.NewTimer(time.Duration(m.config.TimeoutSeconds) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case msg := <-result:
+		return msg
+	case <-timer.C:
+		go func() {
+			if late, ok := (<-result).(initMsg); ok && late.rtm != nil {
+				late.rtm.Disconnect()
+			}
+		}()
+		return timeoutErrMsg{appError: appError{text: text}, retry: retry}
+	}
+}
+
+// Retry the request that timed out, leaving the error state
+func (m *Model) retryTimedOut() tea.Cmd {
+	retry := m.retry
+	m.retry = nil
+	m.error = ""
+	m.isLoading = true
+	return retry
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAwaitTimeout(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.config.TimeoutSeconds = 1
+
+	// A request that doesn't answer until the test is over
+	stuck := make(chan struct{})
+	defer close(stuck)
+	request := func() tea.Msg {
+		<-stuck
+		return nil
+	}
+	retried := false
+	retry := func() tea.Msg {
+		retried = true
+		return nil
+	}
+
+	msg, ok := m.awaitTimeout(tr("error.connect_timeout"), request, retry).(timeoutErrMsg)
+	if !ok {
+		t.Fatalf("awaitTimeout = %T, want timeoutErrMsg", msg)
+	}
+
+	updated, _ := m.handleMsg(msg)
+	m = updated.(Model)
+	if !strings.Contains(m.error, tr("error.connect_timeout")) || !strings.Contains(m.error, tr("error.timeout_hint")) {
+		t.Errorf("error = %q, want the timeout and how to retry", m.error)
+	}
+
+	// r offers the same request again
+	updated, cmd := m.handleMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(Model)
+	runCmd(cmd)
+	if !retried || m.error != "" {
+		t.Errorf("r retried %v, error %q; want a retry and the error cleared", retried, m.error)
+	}
+}
+
+func TestAwaitTimeoutInTime(t *testing.T) {
+	m := newTestModel(&fakeSlack{})
+	m.config.TimeoutSeconds = 1
+
+	want := actionResultMsg{text: "done"}
+	if got := m.awaitTimeout("slow", func() tea.Msg { return want }, nil); got != want {
+		t.Errorf("awaitTimeout = %+v, want the request's own result", got)
+	}
+}
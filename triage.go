@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// How far back to look for mentions
+	mentionsLookback = 7 * 24 * time.Hour
+
+	// Channels scanned for mentions, to stay within rate limits
+	mentionsChannelLimit = 20
+
+	// Messages read per channel
+	mentionsHistoryLimit = 100
+)
+
+// MentionItem represents a mention of the user that hasn't been answered yet
+type MentionItem struct {
+	msg SlackMessage
+}
+
+// Implement the list.Item interface
+func (i MentionItem) Title() string {
+	return fmt.Sprintf("#%s • %s • %s", i.msg.Channel, i.msg.User, i.msg.Time.Format("Mon 15:04"))
+}
+func (i MentionItem) Description() string {
+	return strings.Join(strings.Fields(i.msg.Content), " ")
+}
+func (i MentionItem) FilterValue() string {
+	return i.msg.Channel + " " + i.msg.User + " " + i.msg.Content
+}
+
+type mentionsMsg struct {
+	mentions []SlackMessage
+	err      error
+}
+
+// Open the needs-reply page and scan for unanswered mentions
+func (m *Model) openMentions() tea.Cmd {
+	m.currentPage = pageMentions
+	m.isLoading = true
+	return m.fetchMentions
+}
+
+// Find recent mentions of the user that they haven't answered, either in the
+// mention's thread or with a later post in the channel. Thread replies that
+// mention the user aren't in the channel history, so those are missed.
+func (m *Model) fetchMentions() tea.Msg {
+	if m.slackClient == nil {
+		return genericErr(tr("error.client_missing"))
+	}
+
+	mention := "<@" + m.userID + ">"
+	oldest := fmt.Sprintf("%d.000000", time.Now().Add(-mentionsLookback).Unix())
+
+	var mentions []SlackMessage
+	scanned := 0
+	for _, channel := range m.channels {
+		if !channel.IsMember {
+			continue
+		}
+		if scanned == mentionsChannelLimit {
+			break
+		}
+		scanned++
+
+		history, err := m.slackClient.GetConversationHistory(&slack.GetConversationHistoryParameters{
+			ChannelID: channel.ID,
+			Oldest:    oldest,
+			Limit:     mentionsHistoryLimit,
+		})
+		if err != nil {
+			return mentionsMsg{err: err}
+		}
+
+		// History is newest first, so the user's latest post is the first one found
+		lastOwnPost := ""
+		for _, msg := range history.Messages {
+			if msg.User == m.userID {
+				lastOwnPost = msg.Timestamp
+				break
+			}
+		}
+
+		for _, msg := range history.Messages {
+			if msg.User == m.userID || !strings.Contains(msg.Text, mention) {
+				continue
+			}
+			if msg.Timestamp < lastOwnPost || repliedInThread(msg, m.userID) {
+				continue
+			}
+
+			m.cacheMentionedUsers(msg.Text)
+			mentions = append(mentions, SlackMessage{
+				User:      m.messageAuthor(msg.Msg),
+				UserID:    msg.User,
+				Content:   msg.Text,
+				Channel:   channel.Name,
+				ChannelID: channel.ID,
+				Timestamp: msg.Timestamp,
+				Time:      parseSlackTimestamp(msg.Timestamp),
+				Files:     msg.Files,
+				Edited:    msg.Edited,
+				IsStarred: msg.IsStarred,
+				ThreadTS:  msg.ThreadTimestamp,
+			})
+		}
+	}
+
+	// Oldest first, since those have waited longest
+	sort.Slice(mentions, func(i, j int) bool {
+		return mentions[i].Timestamp < mentions[j].Timestamp
+	})
+
+	return mentionsMsg{mentions: mentions}
+}
+
+// Whether the user has replied in the message's thread
+func repliedInThread(msg slack.Message, userID string) bool {
+	for _, id := range msg.ReplyUsers {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Fill the needs-reply list with the scanned mentions
+func (m *Model) handleMentions(msg mentionsMsg) tea.Cmd {
+	m.isLoading = false
+	if msg.err != nil {
+		return m.showToast(fmt.Sprintf("%s: %v", tr("error.fetch_mentions"), msg.err), true)
+	}
+
+	items := make([]list.Item, len(msg.mentions))
+	for i, mention := range msg.mentions {
+		items[i] = MentionItem{msg: mention}
+	}
+	m.mentions.Title = fmt.Sprintf(tr("mentions.title"), len(items))
+	return m.mentions.SetItems(items)
+}
+
+// Handle input on the needs-reply page, replying in the thread of the chosen mention
+func (m *Model) updateMentions(msg tea.Msg) tea.Cmd {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "enter" && m.mentions.FilterState() != list.Filtering {
+		if item, ok := m.mentions.SelectedItem().(MentionItem); ok {
+			m.startCompose(composeReply, item.msg)
+			m.composeReturn = pageMentions
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.mentions, cmd = m.mentions.Update(msg)
+	return cmd
+}
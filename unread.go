@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// How long fetched unread counts are shown before they're fetched again
+	unreadTTL = time.Minute
+
+	// Channels and direct messages whose unread counts are kept, to stay inside the rate limit
+	unreadFetchLimit = 50
+)
+
+// A channel's cached unread state
+type channelUnread struct {
+	count   int
+	unread  bool
+	fetched time.Time
+}
+
+type unreadMsg struct {
+	channelID string
+	count     int
+	unread    bool
+	err       error
+}
+
+// The picker's badge for a channel's unread messages, e.g. "(3)"; empty when it's all read
+func (u channelUnread) badge() string {
+	switch {
+	case u.count > 0:
+		return fmt.Sprintf(tr("channels.unread"), u.count)
+	case u.unread:
+		return tr("channels.unread_new")
+	default:
+		return ""
+	}
+}
+
+// Whether a channel has messages newer than the user last read, going by the
+// count Slack keeps or, without one, the latest message's timestamp
+func isUnread(ch slack.Channel) bool {
+	if ch.UnreadCountDisplay > 0 || ch.UnreadCount > 0 {
+		return true
+	}
+	if ch.Latest == nil || ch.Latest.Timestamp == "" {
+		return false
+	}
+	return parseSlackTimestamp(ch.Latest.Timestamp).After(parseSlackTimestamp(ch.LastRead))
+}
+
+// Fetch the unread counts that aren't cached or have gone stale, one request per channel
+func (m *Model) fetchUnreadCounts() tea.Cmd {
+	if m.slackClient == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for i, ch := range append(append([]slack.Channel(nil), m.channels...), m.directMessages...) {
+		if i == unreadFetchLimit {
+			break
+		}
+		if m.unreadRequested[ch.ID] {
+			continue
+		}
+		if cached, ok := m.unreads[ch.ID]; ok && time.Since(cached.fetched) < unreadTTL {
+			continue
+		}
+
+		m.unreadRequested[ch.ID] = true
+		channelID := ch.ID
+		cmds = append(cmds, func() tea.Msg {
+			info, err := m.slackClient.GetConversationInfo(&slack.GetConversationInfoInput{ChannelID: channelID})
+			if err != nil {
+				return unreadMsg{channelID: channelID, err: err}
+			}
+			return unreadMsg{channelID: channelID, count: info.UnreadCountDisplay, unread: isUnread(*info)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// Cache a fetched unread count and show it in the picker
+func (m *Model) handleUnread(msg unreadMsg) tea.Cmd {
+	delete(m.unreadRequested, msg.channelID)
+	if msg.err != nil {
+		return nil
+	}
+
+	m.unreads[msg.channelID] = channelUnread{count: msg.count, unread: msg.unread, fetched: time.Now()}
+	if m.currentPage != pageChannelList {
+		return nil
+	}
+	return m.refreshChannelList()
+}
+
+// Forget a channel's unread count once it's been marked as read
+func (m *Model) clearUnread(channelID string) {
+	if _, ok := m.unreads[channelID]; ok {
+		m.unreads[channelID] = channelUnread{fetched: time.Now()}
+	}
+}
+
+// Move the channels with unread messages to the top, keeping each group's order
+func (m Model) sortByUnread(channels []slack.Channel) {
+	sort.SliceStable(channels, func(i, j int) bool {
+		return m.unreads[channels[i].ID].unread && !m.unreads[channels[j].ID].unread
+	})
+}
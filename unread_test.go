@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+// A channel last read at lastRead whose newest message is at latest
+func readChannel(id, name, lastRead, latest string) slack.Channel {
+	ch := testChannel(id, name)
+	ch.LastRead = lastRead
+	if latest != "" {
+		ch.Latest = &slack.Message{Msg: slack.Msg{Timestamp: latest}}
+	}
+	return ch
+}
+
+func TestIsUnread(t *testing.T) {
+	tests := []struct {
+		name string
+		ch   slack.Channel
+		want bool
+	}{
+		{"newer than last read", readChannel("C1", "general", "1700000001.000000", "1700000002.000000"), true},
+		{"read up to latest", readChannel("C1", "general", "1700000002.000000", "1700000002.000000"), false},
+		{"never read", readChannel("C1", "general", "", "1700000002.000000"), true},
+		{"no messages", readChannel("C1", "general", "1700000001.000000", ""), false},
+	}
+	for _, tt := range tests {
+		if got := isUnread(tt.ch); got != tt.want {
+			t.Errorf("%s: isUnread = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnreadBadgeInPicker(t *testing.T) {
+	general := readChannel("C1", "general", "1700000001.000000", "1700000002.000000")
+	client := &fakeSlack{info: map[string]*slack.Channel{"C1": &general}}
+	m := newTestModel(client)
+	m.channels = m.channels[:1]
+	m.currentPage = pageChannelList
+
+	for _, msg := range runCmd(m.fetchUnreadCounts()) {
+		updated, _ := m.handleMsg(msg)
+		m = updated.(Model)
+	}
+
+	items := m.channelItems("")
+	if len(items) != 2 {
+		t.Fatalf("got %d picker items, want all channels and #general", len(items))
+	}
+	if badge := items[1].(ChannelItem).unread; badge != tr("channels.unread_new") {
+		t.Errorf("#general badge = %q, want %q", badge, tr("channels.unread_new"))
+	}
+}
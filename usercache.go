@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// userCache maps user IDs to display names. Commands fill it from their own
+// goroutines, so it's shared by pointer and locked.
+type userCache struct {
+	mu    sync.Mutex
+	names map[string]string
+}
+
+func newUserCache() *userCache {
+	return &userCache{names: make(map[string]string)}
+}
+
+func (c *userCache) get(userID string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	name, ok := c.names[userID]
+	return name, ok
+}
+
+func (c *userCache) set(userID, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.names[userID] = name
+}
+
+type usersLoadedMsg struct {
+	count  int
+	manual bool
+	err    error
+}
+
+type usersRefreshMsg struct {
+	seq int
+}
+
+// Name to show for a user, looked up once and then served from the cache
+func (m *Model) lookupUserName(userID string) string {
+	// Bot and system messages have no user to look up
+	if userID == "" {
+		return tr("messages.unknown")
+	}
+	if name, ok := m.userNames.get(userID); ok {
+		return name
+	}
+
+	user, err := m.slackClient.GetUserInfo(userID)
+	if err != nil {
+		return tr("messages.unknown")
+	}
+	m.userNames.set(userID, user.Name)
+	return user.Name
+}
+
+// Name to show for a message's author. Bots name themselves in the message, so
+// they never need a lookup.
+func (m *Model) messageAuthor(msg slack.Msg) string {
+	if msg.User == "" && msg.BotID != "" {
+		switch {
+		case msg.Username != "":
+			return msg.Username
+		case msg.BotProfile != nil && msg.BotProfile.Name != "":
+			return msg.BotProfile.Name
+		}
+	}
+	return m.lookupUserName(msg.User)
+}
+
+// User mentions in message text, e.g. <@U123> or <@U123|alice>
+var userMentionPattern = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+
+// Look up the people a message mentions, so the text can name them when it's rendered
+func (m *Model) cacheMentionedUsers(text string) {
+	for _, match := range userMentionPattern.FindAllStringSubmatch(text, -1) {
+		m.lookupUserName(match[1])
+	}
+}
+
+// Load the whole user directory into the cache, so rendering never waits on a lookup
+func (m *Model) prefetchUsers(manual bool) tea.Cmd {
+	return func() tea.Msg {
+		users, err := m.slackClient.GetUsers()
+		if err != nil {
+			return usersLoadedMsg{manual: manual, err: err}
+		}
+		for _, user := range users {
+			m.userNames.set(user.ID, user.Name)
+		}
+		return usersLoadedMsg{count: len(users), manual: manual}
+	}
+}
+
+// Schedule the next reload of the user directory, if prefetching is on
+func (m *Model) restartUsersRefresh() tea.Cmd {
+	m.usersRefreshSeq++
+	if !m.config.PrefetchUsers || m.config.UserRefreshMinutes <= 0 {
+		return nil
+	}
+	seq := m.usersRefreshSeq
+	return tea.Tick(time.Duration(m.config.UserRefreshMinutes)*time.Minute, func(time.Time) tea.Msg {
+		return usersRefreshMsg{seq: seq}
+	})
+}
+
+// Report a finished directory load and schedule the next one
+func (m *Model) handleUsersLoaded(msg usersLoadedMsg) tea.Cmd {
+	cmds := []tea.Cmd{m.restartUsersRefresh()}
+	switch {
+	case msg.err != nil:
+		cmds = append(cmds, m.showToast(fmt.Sprintf(tr("toast.users_failed"), msg.err), true))
+	case msg.manual:
+		cmds = append(cmds, m.showToast(fmt.Sprintf(tr("toast.users_loaded"), msg.count), false))
+	}
+	return tea.Batch(cmds...)
+}
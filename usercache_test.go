@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestLookupUserNameCached(t *testing.T) {
+	client := &fakeSlack{users: map[string]string{"U1": "alice"}}
+	m := newTestModel(client)
+
+	for i := 0; i < 2; i++ {
+		if got := m.lookupUserName("U1"); got != "alice" {
+			t.Errorf("lookupUserName(U1) = %q, want alice", got)
+		}
+	}
+	if client.userLookups != 1 {
+		t.Errorf("looked U1 up %d times, want 1", client.userLookups)
+	}
+}
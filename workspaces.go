@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/slack-go/slack"
+)
+
+// The workspace to start in: the default token's, or the first configured one when there's no default token
+func (c Config) startupWorkspace() string {
+	if token, _, err := c.slackToken(); token != "" || err != nil || len(c.Workspaces) == 0 {
+		return ""
+	}
+	return c.Workspaces[0].Name
+}
+
+// Name of the workspace connected to, for the header: its configured name, or
+// the team's domain for the default token
+func (m Model) workspaceLabel() string {
+	if m.workspace != "" {
+		return m.workspace
+	}
+	return m.teamDomain
+}
+
+// The team's domain from its workspace URL, e.g. "acme" for https://acme.slack.com/
+func teamDomainFromURL(workspaceURL string) string {
+	parsed, err := url.Parse(workspaceURL)
+	if err != nil {
+		return ""
+	}
+	domain, _, _ := strings.Cut(parsed.Hostname(), ".")
+	return domain
+}
+
+// List the workspaces to switch to, the default token's first if there is one
+func (m *Model) openWorkspaces() tea.Cmd {
+	if len(m.config.Workspaces) == 0 {
+		return m.showToast(tr("toast.no_workspaces"), true)
+	}
+
+	var items []list.Item
+	if token, _, _ := m.config.slackToken(); token != "" {
+		items = append(items, QuickAction{id: "", name: tr("workspaces.default"), description: tr("workspaces.default.d")})
+	}
+	for _, ws := range m.config.Workspaces {
+		items = append(items, QuickAction{id: ws.Name, name: ws.Name, description: tr("workspaces.other.d")})
+	}
+
+	selected := 0
+	for i, item := range items {
+		if action := item.(QuickAction); action.id == m.workspace {
+			action.description = tr("workspaces.active.d")
+			items[i] = action
+			selected = i
+		}
+	}
+
+	m.workspaceList.SetItems(items)
+	m.workspaceList.Select(selected)
+	m.currentPage = pageWorkspaces
+	return nil
+}
+
+// Disconnect from the current workspace and connect to another, starting over
+// with its channels and people
+func (m *Model) switchWorkspace(name string) tea.Cmd {
+	if m.rtm != nil {
+		m.rtm.Disconnect()
+	}
+
+	m.workspace = name
+	m.slackClient = nil
+	m.rtm = nil
+	m.userID, m.userName, m.teamDomain = "", "", ""
+	m.channels, m.directMessages = nil, nil
+	m.messages, m.pendingMessages = nil, nil
+	m.selectedChannelID, m.focusedChannelID = "", ""
+	m.selectedMessage = 0
+	m.fromUserID = ""
+	m.clearSearch()
+	m.historyCursor = ""
+	m.pins, m.pinsChannelID = nil, ""
+	m.users, m.usersComplete = nil, false
+	m.userPages, m.usersFetching = slack.UserPagination{}, false
+	m.people.ResetFilter()
+	m.people.SetItems(nil)
+	m.userNames = newUserCache()
+	m.presence = make(map[string]string)
+	m.presenceRequested = make(map[string]bool)
+	m.sparklines = make(map[string]sparkline)
+	m.sparklineRequested = make(map[string]bool)
+	m.unreads = make(map[string]channelUnread)
+	m.channelInfo = make(map[string]*slack.Channel)
+	m.unreadRequested = make(map[string]bool)
+	m.error = ""
+
+	m.currentPage = pageMain
+	m.isLoading = true
+	m.refreshMessages()
+	return tea.Batch(
+		func() tea.Msg { return m.connectWorkspace(name) },
+		m.showToast(fmt.Sprintf(tr("toast.switching_workspace"), m.workspaceName(name)), false),
+	)
+}
+
+// A workspace's name as the switcher shows it
+func (m Model) workspaceName(name string) string {
+	if name == "" {
+		return tr("workspaces.default")
+	}
+	return name
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/slack-go/slack"
+)
+
+func TestSwitchWorkspaceReplacesClient(t *testing.T) {
+	old := &fakeSlack{}
+	m := newTestModel(old)
+	m.config.Workspaces = []Workspace{{Name: "beta", Token: "xoxp-beta"}}
+	m.selectedChannelID = "C2"
+	m.messages = []SlackMessage{{ChannelID: "C2", Content: "random"}}
+	alice := slack.User{ID: "U1", Name: "alice"}
+	m.users = []slack.User{alice}
+	m.people.SetItems([]list.Item{PersonItem{user: alice}})
+	m.usersFetching = true
+
+	if cmd := m.switchWorkspace("beta"); cmd == nil {
+		t.Fatal("switchWorkspace didn't connect to the new workspace")
+	}
+	if m.slackClient != nil || m.channels != nil || m.messages != nil || m.selectedChannelID != "" {
+		t.Errorf("the old workspace's client, channels or messages were kept")
+	}
+	if m.workspace != "beta" || !m.isLoading {
+		t.Errorf("workspace = %q, loading %v; want beta, loading", m.workspace, m.isLoading)
+	}
+	if len(m.people.Items()) != 0 || m.users != nil || m.usersFetching {
+		t.Errorf("the old workspace's people were kept: %d listed, fetching %v", len(m.people.Items()), m.usersFetching)
+	}
+
+	// A page of the old workspace's people that lands after the switch is dropped
+	updated, _ := m.handleMsg(usersPageMsg{workspace: "", page: slack.UserPagination{Users: []slack.User{alice}, Cursor: "next"}})
+	m = updated.(Model)
+	if len(m.people.Items()) != 0 {
+		t.Errorf("a stale page added %d people to the new workspace", len(m.people.Items()))
+	}
+
+	client := &fakeSlack{}
+	updated, _ = m.handleMsg(initMsg{workspace: "beta", client: client, channels: []slack.Channel{testChannel("D1", "beta-general")}})
+	m = updated.(Model)
+	if m.slackClient != client {
+		t.Error("the new workspace's client wasn't used")
+	}
+	if len(m.channels) != 1 || m.channels[0].ID != "D1" {
+		t.Errorf("channels = %+v, want the new workspace's", m.channels)
+	}
+}